@@ -47,6 +47,11 @@ command -v operator-sdk >/dev/null 2>&1 || { echo -e "${RED}operator-sdk is not
 operatorVersion=$(operator-sdk version)
 [[ $operatorVersion =~ .*v0.17.0.* ]] || { echo -e "${RED}operator-sdk v0.17.0 is required${NC}"; exit 1; }
 
+for generator in client-gen lister-gen informer-gen
+do
+  command -v ${generator} >/dev/null 2>&1 || { echo -e "${RED}${generator} is not installed (from k8s.io/code-generator v0.17.4). Aborting.${NC}"; exit 1; }
+done
+
 set -e
 
 BASE_DIR=$(cd "$(dirname "$0")" && pwd)
@@ -55,6 +60,21 @@ mkdir -p "${BASE_DIR}/generated"
 
 operator-sdk generate k8s
 operator-sdk generate crds
+
+API_PACKAGE="github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+CLIENT_PACKAGE="github.com/devfile/api/pkg/client"
+CODEGEN_OUTPUT=$(mktemp -d)
+client-gen --clientset-name versioned --input-base "" --input "${API_PACKAGE}" \
+  --output-package "${CLIENT_PACKAGE}/clientset" --go-header-file /dev/null --output-base "${CODEGEN_OUTPUT}"
+lister-gen --input-dirs "${API_PACKAGE}" \
+  --output-package "${CLIENT_PACKAGE}/listers" --go-header-file /dev/null --output-base "${CODEGEN_OUTPUT}"
+informer-gen --input-dirs "${API_PACKAGE}" \
+  --versioned-clientset-package "${CLIENT_PACKAGE}/clientset/versioned" --listers-package "${CLIENT_PACKAGE}/listers" \
+  --output-package "${CLIENT_PACKAGE}/informers" --go-header-file /dev/null --output-base "${CODEGEN_OUTPUT}"
+rm -rf "${BASE_DIR}/pkg/client"
+cp -r "${CODEGEN_OUTPUT}/${CLIENT_PACKAGE}" "${BASE_DIR}/pkg/client"
+rm -rf "${CODEGEN_OUTPUT}"
+
 yq '.spec.validation.openAPIV3Schema' \
   "${BASE_DIR}/deploy/crds/workspace.devfile.io_devworkspaces_crd.yaml" \
   > "${BASE_DIR}/schemas/devworkspace.json"
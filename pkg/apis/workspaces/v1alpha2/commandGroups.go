@@ -0,0 +1,124 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GetGroup returns the group the command is part of, or nil if the command is not part of a group.
+func (command Command) GetGroup() *CommandGroup {
+	var group *CommandGroup
+	command.Visit(CommandVisitor{
+		Apply: func(apply *ApplyCommand) error {
+			group = apply.Group
+			return nil
+		},
+		Exec: func(exec *ExecCommand) error {
+			group = exec.Group
+			return nil
+		},
+		VscodeTask: func(task *VscodeConfigurationCommand) error {
+			group = task.Group
+			return nil
+		},
+		VscodeLaunch: func(launch *VscodeConfigurationCommand) error {
+			group = launch.Group
+			return nil
+		},
+		Composite: func(composite *CompositeCommand) error {
+			group = composite.Group
+			return nil
+		},
+		Custom: func(custom *CustomCommand) error {
+			group = custom.Group
+			return nil
+		},
+	})
+	return group
+}
+
+// NoCommandInGroupError is returned when looking for the default command of a group that has no command.
+//
+// +k8s:deepcopy-gen=false
+type NoCommandInGroupError struct {
+	Kind CommandGroupKind
+}
+
+func (e *NoCommandInGroupError) Error() string {
+	return fmt.Sprintf("the '%s' command group has no command", e.Kind)
+}
+
+// NoDefaultCommandError is returned when looking for the default command of a group
+// that has several commands, none of which is the default one.
+//
+// +k8s:deepcopy-gen=false
+type NoDefaultCommandError struct {
+	Kind CommandGroupKind
+	// Ids of the commands of the group
+	Commands []string
+}
+
+func (e *NoDefaultCommandError) Error() string {
+	return fmt.Sprintf("the '%s' command group has several commands, but none of them is the default one: %s", e.Kind, quotedIds(e.Commands))
+}
+
+// MultipleDefaultCommandsError is returned when looking for the default command of a group
+// that has several default commands.
+//
+// +k8s:deepcopy-gen=false
+type MultipleDefaultCommandsError struct {
+	Kind CommandGroupKind
+	// Ids of the default commands of the group
+	Commands []string
+}
+
+func (e *MultipleDefaultCommandsError) Error() string {
+	return fmt.Sprintf("the '%s' command group has several default commands: %s", e.Kind, quotedIds(e.Commands))
+}
+
+func quotedIds(ids []string) string {
+	var quoted []string
+	for _, id := range ids {
+		quoted = append(quoted, "'"+id+"'")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// GetDefaultCommand returns the default command of a command group:
+//
+// - the command of the group that has `isDefault: true`,
+//
+// - or, if none of them is the default one, the command of the group when it is the only one.
+//
+// It returns a `*NoCommandInGroupError` if the group has no command, a `*NoDefaultCommandError`
+// if the group has several commands and none is the default one, and a `*MultipleDefaultCommandsError`
+// if the group has several default commands.
+//
+// The content is expected to be flattened, since parents and plugins may add commands to the group.
+func (content *DevWorkspaceTemplateSpecContent) GetDefaultCommand(kind CommandGroupKind) (*Command, error) {
+	var inGroup, defaults []*Command
+	var inGroupIds, defaultIds []string
+	for i := range content.Commands {
+		group := content.Commands[i].GetGroup()
+		if group == nil || group.Kind != kind {
+			continue
+		}
+		inGroup = append(inGroup, &content.Commands[i])
+		inGroupIds = append(inGroupIds, content.Commands[i].Id)
+		if group.IsDefault {
+			defaults = append(defaults, &content.Commands[i])
+			defaultIds = append(defaultIds, content.Commands[i].Id)
+		}
+	}
+	switch {
+	case len(defaults) == 1:
+		return defaults[0], nil
+	case len(defaults) > 1:
+		return nil, &MultipleDefaultCommandsError{Kind: kind, Commands: defaultIds}
+	case len(inGroup) == 1:
+		return inGroup[0], nil
+	case len(inGroup) > 1:
+		return nil, &NoDefaultCommandError{Kind: kind, Commands: inGroupIds}
+	}
+	return nil, &NoCommandInGroupError{Kind: kind}
+}
@@ -0,0 +1,57 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func execCommand(id string, group *CommandGroup) Command {
+	return Command{
+		Id: id,
+		CommandUnion: CommandUnion{
+			Exec: &ExecCommand{
+				LabeledCommand: LabeledCommand{BaseCommand: BaseCommand{Group: group}},
+				CommandLine:    "make " + id,
+				Component:      "tools",
+			},
+		},
+	}
+}
+
+func TestGetDefaultCommand(t *testing.T) {
+	content := DevWorkspaceTemplateSpecContent{
+		Commands: []Command{
+			execCommand("build", &CommandGroup{Kind: BuildCommandGroupKind}),
+			execCommand("run", &CommandGroup{Kind: RunCommandGroupKind}),
+			execCommand("run-debug", &CommandGroup{Kind: RunCommandGroupKind, IsDefault: true}),
+			execCommand("test", &CommandGroup{Kind: TestCommandGroupKind}),
+			execCommand("test-e2e", &CommandGroup{Kind: TestCommandGroupKind}),
+			execCommand("debug", &CommandGroup{Kind: DebugCommandGroupKind, IsDefault: true}),
+			execCommand("debug-remote", &CommandGroup{Kind: DebugCommandGroupKind, IsDefault: true}),
+			execCommand("clean", nil),
+		},
+	}
+
+	command, err := content.GetDefaultCommand(BuildCommandGroupKind)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "build", command.Id, "the only command of a group should be the default one")
+	}
+
+	command, err = content.GetDefaultCommand(RunCommandGroupKind)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "run-debug", command.Id)
+	}
+
+	_, err = content.GetDefaultCommand(TestCommandGroupKind)
+	assert.Equal(t, &NoDefaultCommandError{Kind: TestCommandGroupKind, Commands: []string{"test", "test-e2e"}}, err)
+	assert.EqualError(t, err, "the 'test' command group has several commands, but none of them is the default one: 'test', 'test-e2e'")
+
+	_, err = content.GetDefaultCommand(DebugCommandGroupKind)
+	assert.Equal(t, &MultipleDefaultCommandsError{Kind: DebugCommandGroupKind, Commands: []string{"debug", "debug-remote"}}, err)
+	assert.EqualError(t, err, "the 'debug' command group has several default commands: 'debug', 'debug-remote'")
+
+	_, err = (&DevWorkspaceTemplateSpecContent{}).GetDefaultCommand(BuildCommandGroupKind)
+	assert.Equal(t, &NoCommandInGroupError{Kind: BuildCommandGroupKind}, err)
+	assert.EqualError(t, err, "the 'build' command group has no command")
+}
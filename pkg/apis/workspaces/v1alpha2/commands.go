@@ -130,6 +130,8 @@ type ExecCommand struct {
 	//  - `${PROJECTS_ROOT}`: A path where projects sources are mounted
 	//
 	//  - `${PROJECT_SOURCE}`: A path to a project source (${PROJECTS_ROOT}/<project-name>). If there are multiple projects, this will point to the directory of the first one.
+	//
+	// It should not use `..` to climb above the directory it starts from.
 	WorkingDir string `json:"workingDir,omitempty"`
 
 	// +optional
@@ -4,7 +4,7 @@ import runtime "k8s.io/apimachinery/pkg/runtime"
 
 // ComponentType describes the type of component.
 // Only one of the following component type may be specified.
-// +kubebuilder:validation:Enum=Container;Kubernetes;Openshift;Volume;Plugin;Custom
+// +kubebuilder:validation:Enum=Container;Kubernetes;Openshift;Volume;Image;Plugin;Custom
 type ComponentType string
 
 const (
@@ -13,6 +13,7 @@ const (
 	OpenshiftComponentType  ComponentType = "Openshift"
 	PluginComponentType     ComponentType = "Plugin"
 	VolumeComponentType     ComponentType = "Volume"
+	ImageComponentType      ComponentType = "Image"
 	CustomComponentType     ComponentType = "Custom"
 )
 
@@ -47,6 +48,11 @@ type ComponentUnion struct {
 	// +optional
 	Volume *VolumeComponent `json:"volume,omitempty"`
 
+	// Allows building a container image from a Dockerfile,
+	// so that container components can run an image built from the project sources
+	// +optional
+	Image *ImageComponent `json:"image,omitempty"`
+
 	// Allows importing a plugin.
 	//
 	// Plugins are mainly imported devfiles that contribute components, commands
@@ -93,7 +99,7 @@ type CustomComponent struct {
 // PluginComponentsOverrideType describes the type of components
 // that can be overriden for a plugin.
 // Only one of the following component type may be specified.
-// +kubebuilder:validation:Enum=Container;Kubernetes;Openshift;Volume
+// +kubebuilder:validation:Enum=Container;Kubernetes;Openshift;Volume;Image
 type PluginComponentsOverrideType string
 
 const (
@@ -101,6 +107,7 @@ const (
 	KubernetesPluginComponentsOverrideType PluginComponentsOverrideType = "Kubernetes"
 	OpenshiftPluginComponentsOverrideType  PluginComponentsOverrideType = "Openshift"
 	VolumePluginComponentsOverrideType     PluginComponentsOverrideType = "Volume"
+	ImagePluginComponentsOverrideType      PluginComponentsOverrideType = "Image"
 )
 
 //+k8s:openapi-gen=true
@@ -135,4 +142,8 @@ type PluginComponentsOverrideUnion struct {
 	// Configuration overriding for an OpenShift component in a plugin
 	// +optional
 	Openshift *OpenshiftComponent `json:"openshift,omitempty"`
+
+	// Configuration overriding for an Image component in a plugin
+	// +optional
+	Image *ImageComponent `json:"image,omitempty"`
 }
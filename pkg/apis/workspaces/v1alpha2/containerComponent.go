@@ -4,11 +4,23 @@ package v1alpha2
 type ContainerComponent struct {
 	BaseComponent `json:",inline"`
 	Container     `json:",inline"`
-	MemoryLimit   string     `json:"memoryLimit,omitempty"`
 	Endpoints     []Endpoint `json:"endpoints,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// Probe that tells when the container is ready to serve its endpoints.
+	// The workspace components are reported as ready only when the readiness probes of all
+	// the containers succeed.
+	// +optional
+	ReadinessProbe *Probe `json:"readinessProbe,omitempty"`
+
+	// Probe that tells when the container is unhealthy and should be restarted.
+	// +optional
+	LivenessProbe *Probe `json:"livenessProbe,omitempty"`
 }
 
 type Container struct {
+	// Image run by the container.
+	// When it is the name of an `image` component, the container runs
+	// the image built by this component.
 	Image string `json:"image,omitempty"`
 
 	// +optional
@@ -19,9 +31,36 @@ type Container struct {
 	// List of volumes mounts that should be mounted is this container.
 	VolumeMounts []VolumeMount `json:"volumeMounts,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 
+	// Maximum amount of memory the container can use,
+	// expressed as a Kubernetes resource quantity, such as `512Mi` or `1Gi`.
 	// +optional
+	// +kubebuilder:validation:Pattern=`^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$`
 	MemoryLimit string `json:"memoryLimit,omitempty"`
 
+	// Amount of memory reserved for the container,
+	// expressed as a Kubernetes resource quantity, such as `256Mi`.
+	// It should not be greater than `memoryLimit`.
+	//
+	// Defaults to `memoryLimit` when omitted.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$`
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+
+	// Maximum amount of CPU the container can use,
+	// expressed as a Kubernetes resource quantity, such as `1` or `500m`.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$`
+	CpuLimit string `json:"cpuLimit,omitempty"`
+
+	// Amount of CPU reserved for the container,
+	// expressed as a Kubernetes resource quantity, such as `100m`.
+	// It should not be greater than `cpuLimit`.
+	//
+	// Defaults to `cpuLimit` when omitted.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$`
+	CpuRequest string `json:"cpuRequest,omitempty"`
+
 	// The command to run in the dockerimage component instead of the default one provided in the image.
 	//
 	// Defaults to an empty array, meaning use whatever is defined in the image.
@@ -40,6 +79,9 @@ type Container struct {
 	// Optional specification of the path in the container where
 	// project sources should be transferred/mounted when `mountSources` is `true`.
 	// When omitted, the value of the `PROJECTS_ROOT` environment variable is used.
+	//
+	// It should be an absolute path, that doesn't use `..` to climb above the root directory,
+	// and that is not a system directory, such as `/etc` or `/usr`.
 	// +optional
 	SourceMapping string `json:"sourceMapping,omitempty"`
 
@@ -52,8 +94,80 @@ type Container struct {
 }
 
 type EnvVar struct {
-	Name  string `json:"name" yaml:"name"`
+	Name string `json:"name" yaml:"name"`
+
+	// Value of the environment variable.
+	// It should not be set when the value comes from `valueFrom`:
+	// the validation rejects variables that have both a value and a `valueFrom` source.
+	// +optional
 	Value string `json:"value" yaml:"value"`
+
+	// Source of the value of the environment variable, when the value is not written in the devfile,
+	// such as a key of a Secret, to avoid writing tokens in plain text.
+	// +optional
+	ValueFrom *EnvVarSource `json:"valueFrom,omitempty" yaml:"valueFrom,omitempty"`
+}
+
+// EnvVarSourceType describes the type of source of the value of an environment variable.
+// Only one of the following source types may be specified.
+// +kubebuilder:validation:Enum=SecretKeyRef;ConfigMapKeyRef;WorkspaceRef
+type EnvVarSourceType string
+
+const (
+	SecretKeyRefEnvVarSourceType    EnvVarSourceType = "SecretKeyRef"
+	ConfigMapKeyRefEnvVarSourceType EnvVarSourceType = "ConfigMapKeyRef"
+	WorkspaceRefEnvVarSourceType    EnvVarSourceType = "WorkspaceRef"
+)
+
+// +union
+type EnvVarSource struct {
+	// Type of source
+	// +
+	// +unionDiscriminator
+	// +optional
+	SourceType EnvVarSourceType `json:"sourceType,omitempty"`
+
+	// Key of a Secret of the workspace namespace
+	// +optional
+	SecretKeyRef *KeySelector `json:"secretKeyRef,omitempty"`
+
+	// Key of a ConfigMap of the workspace namespace
+	// +optional
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty"`
+
+	// Metadata of the workspace
+	// +optional
+	WorkspaceRef *WorkspaceMetadataSelector `json:"workspaceRef,omitempty"`
+}
+
+// Key of a Secret or a ConfigMap
+type KeySelector struct {
+	// Name of the Secret or the ConfigMap
+	Name string `json:"name"`
+
+	// Key whose value is used
+	Key string `json:"key"`
+
+	// Whether the variable is unset when the Secret, the ConfigMap or the key doesn't exist,
+	// instead of preventing the container to start.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// WorkspaceMetadataField is a field of the metadata of a workspace.
+// Only one of the following fields may be specified: name, namespace, id.
+// +kubebuilder:validation:Enum=name;namespace;id
+type WorkspaceMetadataField string
+
+const (
+	NameWorkspaceMetadataField      WorkspaceMetadataField = "name"
+	NamespaceWorkspaceMetadataField WorkspaceMetadataField = "namespace"
+	IdWorkspaceMetadataField        WorkspaceMetadataField = "id"
+)
+
+type WorkspaceMetadataSelector struct {
+	// Field of the workspace metadata whose value is used
+	Field WorkspaceMetadataField `json:"field"`
 }
 
 // Volume that should be mounted to a component container
@@ -65,6 +179,10 @@ type VolumeMount struct {
 
 	// The path in the component container where the volume should be mounted.
 	// If not path is mentioned, default path is the is `/<name>`.
+	//
+	// It should be an absolute path, that doesn't use `..` to climb above the root directory,
+	// and that is not a system directory, such as `/etc` or `/usr`.
+	// The volume mounts of a container should not overlap.
 	// +optional
 	Path string `json:"path,omitempty"`
 }
@@ -0,0 +1,17 @@
+package v1alpha2
+
+// Reference to the credentials used to access a private location,
+// such as a private git repository or devfile registry.
+// The credentials are stored in a Kubernetes secret, in the namespace of the workspace.
+type CredentialsReference struct {
+	// Name of the secret that contains the credentials.
+	//
+	// - Secrets of type `kubernetes.io/ssh-auth` provide an SSH private key in the `ssh-privatekey` key,
+	// and optionally the accepted host keys in the `known_hosts` key.
+	//
+	// - Secrets of type `kubernetes.io/basic-auth` provide a `username` and a `password`.
+	//
+	// - Other secrets provide a token in the `token` key, used as a bearer token over HTTP,
+	// or as a password for git HTTP remotes, with an optional `username`.
+	SecretName string `json:"secretName"`
+}
@@ -23,6 +23,8 @@ type DevWorkspaceStatus struct {
 	Phase  WorkspacePhase `json:"phase,omitempty"`
 	// Conditions represent the latest available observations of an object's state
 	Conditions []WorkspaceCondition `json:"conditions,omitempty"`
+	// Provenance of the parent and plugins the workspace devfile was flattened from
+	Imports []ImportProvenance `json:"imports,omitempty"`
 }
 
 type WorkspacePhase string
@@ -62,6 +64,7 @@ const (
 	WorkspaceFailedStart         WorkspaceConditionType = "FailedStart"
 )
 
+// +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // DevWorkspace is the Schema for the devworkspaces API
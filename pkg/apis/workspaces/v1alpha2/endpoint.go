@@ -1,8 +1,8 @@
 package v1alpha2
 
 // EndpointProtocol defines the application and transport protocols of the traffic that will go through this endpoint.
-// Only one of the following protocols may be specified: http, ws, tcp, udp.
-// +kubebuilder:validation:Enum=http;ws;tcp;udp
+// Only one of the following protocols may be specified: http, https, ws, wss, tcp, udp, grpc, http2.
+// +kubebuilder:validation:Enum=http;https;ws;wss;tcp;udp;grpc;http2
 type EndpointProtocol string
 
 const (
@@ -22,6 +22,12 @@ const (
 	// Endpoint will have traffic on an UDP connection,
 	// without specifying an application protocol
 	UDPEndpointProtocol EndpointProtocol = "udp"
+	// Endpoint will have `grpc` traffic, on an HTTP/2 connection.
+	// It will use TLS when the `secure` field is set to `true`
+	GRPCEndpointProtocol EndpointProtocol = "grpc"
+	// Endpoint will have `http2` traffic, typically on a TCP connection.
+	// It will use TLS when the `secure` field is set to `true`
+	HTTP2EndpointProtocol EndpointProtocol = "http2"
 )
 
 // EndpointExposure describes the way an endpoint is exposed on the network.
@@ -45,7 +51,11 @@ const (
 type Endpoint struct {
 	Name string `json:"name"`
 
+	// Port on which the component listens for the endpoint traffic.
+	// Endpoints of containers that run in the same pod should not target the same port.
 	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
 	TargetPort int `json:"targetPort,omitempty"`
 
 	// Describes how the endpoint should be exposed on the network.
@@ -60,6 +70,9 @@ type Endpoint struct {
 	// - `none` means that the endpoint will not be exposed and will only be accessible
 	// inside the main workspace POD, on a local address.
 	//
+	// Only endpoints with the `http`, `https`, `ws`, `wss`, `grpc` or `http2` protocols
+	// can have a `public` exposure.
+	//
 	// Default value is `public`
 	// +optional
 	Exposure EndpointExposure `json:"exposure,omitempty"`
@@ -80,12 +93,25 @@ type Endpoint struct {
 	//
 	// - `udp`: Endpoint will have traffic on an UDP connection, without specifying an application protocol.
 	//
+	// - `grpc`: Endpoint will have `grpc` traffic, on an HTTP/2 connection.
+	// It will use TLS when the `secure` field is set to `true`.
+	//
+	// - `http2`: Endpoint will have `http2` traffic, typically on a TCP connection.
+	// It will use TLS when the `secure` field is set to `true`.
+	//
 	// Default value is `http`
 	// +optional
-	Protocol string `json:"protocol,omitempty"`
+	Protocol EndpointProtocol `json:"protocol,omitempty"`
 
 	// Describes whether the endpoint should be secured and protected by some
-	// authentication process
+	// authentication process.
+	//
+	// The `https` and `wss` protocols imply a secure endpoint,
+	// so `secure` is always considered `true` with them.
+	//
+	// A secure endpoint should be exposed outside of the main workspace POD
+	// (`public` or `internal` exposure), and should use an application protocol
+	// (`http`, `https`, `ws`, `wss`, `grpc` or `http2`): `tcp` and `udp` endpoints cannot be secured.
 	// +optional
 	Secure bool `json:"secure,omitempty"`
 
@@ -0,0 +1,33 @@
+package v1alpha2
+
+// Component that allows the developer to build a container image from a Dockerfile,
+// and to run it in container components that reference the image component by name
+type ImageComponent struct {
+	BaseComponent `json:",inline"`
+	Image         `json:",inline"`
+}
+
+type Image struct {
+	// Name of the built image, including its registry and tag,
+	// such as `quay.io/user/app:latest`.
+	// It is the image run by the container components whose `image` is the name of the image component.
+	ImageName string `json:"imageName,omitempty"`
+
+	// Path of the Dockerfile, relative to the build context.
+	//
+	// Default value is `Dockerfile`
+	// +optional
+	Dockerfile string `json:"dockerfile,omitempty"`
+
+	// Path of the directory sent as context to the build,
+	// relative to the source of the first project.
+	// It should not use `..` to climb above the project source.
+	//
+	// Default value is `.`
+	// +optional
+	BuildContext string `json:"buildContext,omitempty"`
+
+	// Arguments passed to the build, and available as `ARG` instructions in the Dockerfile
+	// +optional
+	Args []EnvVar `json:"args,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+}
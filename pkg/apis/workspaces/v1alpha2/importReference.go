@@ -46,4 +46,38 @@ type ImportReference struct {
 	ImportReferenceUnion `json:",inline"`
 	// +optional
 	RegistryUrl string `json:"registryUrl,omitempty"`
+
+	// Semantic version constraint of the devfile referenced by `id`,
+	// such as `2.x`, `^2.1.0` or `>=1.2.0 <2.0.0`.
+	// The highest version available in the registry that satisfies the constraint is used.
+	// When empty, the default version of the registry is used.
+	// Only applies to imports referenced by `id`.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Credentials used to retrieve the referenced devfile
+	// from a private uri or registry
+	// +optional
+	Credentials *CredentialsReference `json:"credentials,omitempty"`
+}
+
+// Provenance of a parent or plugin imported while flattening a devfile
+type ImportProvenance struct {
+	// Path of the import in the import tree of the devfile,
+	// such as `parent`, `plugin:theia` or `parent/plugin:theia`
+	Path string `json:"path"`
+
+	// Location the import was retrieved from:
+	// the resolved url for imports referenced by `uri` or `id`,
+	// or `<namespace>/<name>` for imports referenced by `kubernetes`
+	Location string `json:"location"`
+
+	// Version of the imported devfile: the version chosen in the registry
+	// for imports with a version constraint, or else the version declared in the devfile metadata
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Version constraint of the import reference
+	// +optional
+	VersionConstraint string `json:"versionConstraint,omitempty"`
 }
@@ -0,0 +1,91 @@
+package v1alpha2
+
+// ProbeType describes the type of probe.
+// Only one of the following probe types may be specified.
+// +kubebuilder:validation:Enum=Http;Tcp;Exec
+type ProbeType string
+
+const (
+	HttpProbeType ProbeType = "Http"
+	TcpProbeType  ProbeType = "Tcp"
+	ExecProbeType ProbeType = "Exec"
+)
+
+// Health check of a container component, run periodically once the container is started
+type Probe struct {
+	ProbeHandler `json:",inline"`
+
+	// Number of seconds after the container has started before the probe is first run.
+	//
+	// Default value is `0`
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+
+	// Number of seconds between two runs of the probe.
+	//
+	// Default value is `10`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// Number of seconds after which the probe times out.
+	//
+	// Default value is `1`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Number of consecutive failures after which the probe is considered failed.
+	//
+	// Default value is `3`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// +union
+type ProbeHandler struct {
+	// Type of probe
+	// +
+	// +unionDiscriminator
+	// +optional
+	ProbeType ProbeType `json:"probeType,omitempty"`
+
+	// Probe that sends an HTTP `GET` request to an endpoint of the container.
+	// The probe succeeds when the response status is a 2xx or 3xx status.
+	// +optional
+	Http *HttpProbe `json:"http,omitempty"`
+
+	// Probe that opens a TCP connection to an endpoint of the container.
+	// The probe succeeds when the connection is established.
+	// +optional
+	Tcp *TcpProbe `json:"tcp,omitempty"`
+
+	// Probe that runs a command in the container.
+	// The probe succeeds when the command exits with the `0` status.
+	// +optional
+	Exec *ExecProbe `json:"exec,omitempty"`
+}
+
+type HttpProbe struct {
+	// Name of the endpoint of the container the request is sent to
+	Endpoint string `json:"endpoint"`
+
+	// Path of the request.
+	//
+	// Defaults to the path of the endpoint, or `/` when the endpoint has no path.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+type TcpProbe struct {
+	// Name of the endpoint of the container the connection is opened to
+	Endpoint string `json:"endpoint"`
+}
+
+type ExecProbe struct {
+	// Command run in the container, with its arguments.
+	// It is not run in a shell.
+	Command []string `json:"command" patchStrategy:"replace"`
+}
@@ -6,7 +6,7 @@ type Project struct {
 	// Project name
 	Name string `json:"name"`
 
-	// Path relative to the root of the projects to which this project should be cloned into. This is a unix-style relative path (i.e. uses forward slashes). The path is invalid if it is absolute or tries to escape the project root through the usage of '..'. If not specified, defaults to the project name.
+	// Path relative to the root of the projects to which this project should be cloned into. This is a unix-style relative path (i.e. uses forward slashes). The path is invalid if it is absolute or tries to escape the project root through the usage of '..'. If not specified, defaults to the project name. Several projects should not be cloned into the same directory.
 	// +optional
 	ClonePath string `json:"clonePath,omitempty"`
 
@@ -91,6 +91,11 @@ type GitLikeProjectSource struct {
 	// The remotes map which should be initialized in the git project. Must have at least one remote configured
 	// +optional
 	Remotes map[string]string `json:"remotes,omitempty"`
+
+	// Credentials used to access the remotes of a private git repository, by remote name.
+	// Remotes without credentials are accessed anonymously.
+	// +optional
+	Credentials map[string]CredentialsReference `json:"credentials,omitempty"`
 }
 
 type CheckoutFrom struct {
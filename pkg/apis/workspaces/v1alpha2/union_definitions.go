@@ -21,6 +21,7 @@ type ComponentVisitor struct {
 	Container  func(*ContainerComponent) error
 	Plugin     func(*PluginComponent) error
 	Volume     func(*VolumeComponent) error
+	Image      func(*ImageComponent) error
 	Kubernetes func(*KubernetesComponent) error
 	Openshift  func(*OpenshiftComponent) error
 	Custom     func(*CustomComponent) error
@@ -47,6 +48,7 @@ type PluginComponentsOverrideVisitor struct {
 	Volume     func(*VolumeComponent) error
 	Kubernetes func(*KubernetesComponent) error
 	Openshift  func(*OpenshiftComponent) error
+	Image      func(*ImageComponent) error
 }
 
 var pluginComponentsOverrideVisitorType reflect.Type = reflect.TypeOf(PluginComponentsOverrideVisitor{})
@@ -175,3 +177,47 @@ func (union *ProjectSource) Normalize() error {
 func (union *ProjectSource) Simplify() {
 	simplifyUnion(union, projectSourceVisitorType)
 }
+
+// +k8s:deepcopy-gen=false
+type EnvVarSourceVisitor struct {
+	SecretKeyRef    func(*KeySelector) error
+	ConfigMapKeyRef func(*KeySelector) error
+	WorkspaceRef    func(*WorkspaceMetadataSelector) error
+}
+
+var envVarSourceVisitorType reflect.Type = reflect.TypeOf(EnvVarSourceVisitor{})
+
+func (union EnvVarSource) Visit(visitor EnvVarSourceVisitor) error {
+	return visitUnion(union, visitor)
+}
+func (union *EnvVarSource) discriminator() *string {
+	return (*string)(&union.SourceType)
+}
+func (union *EnvVarSource) Normalize() error {
+	return normalizeUnion(union, envVarSourceVisitorType)
+}
+func (union *EnvVarSource) Simplify() {
+	simplifyUnion(union, envVarSourceVisitorType)
+}
+
+// +k8s:deepcopy-gen=false
+type ProbeHandlerVisitor struct {
+	Http func(*HttpProbe) error
+	Tcp  func(*TcpProbe) error
+	Exec func(*ExecProbe) error
+}
+
+var probeHandlerVisitorType reflect.Type = reflect.TypeOf(ProbeHandlerVisitor{})
+
+func (union ProbeHandler) Visit(visitor ProbeHandlerVisitor) error {
+	return visitUnion(union, visitor)
+}
+func (union *ProbeHandler) discriminator() *string {
+	return (*string)(&union.ProbeType)
+}
+func (union *ProbeHandler) Normalize() error {
+	return normalizeUnion(union, probeHandlerVisitorType)
+}
+func (union *ProbeHandler) Simplify() {
+	simplifyUnion(union, probeHandlerVisitorType)
+}
@@ -6,9 +6,76 @@ type VolumeComponent struct {
 	Volume        `json:",inline"`
 }
 
+// VolumeAccessMode describes how the volume can be mounted by the nodes of the cluster.
+// Only one of the following access modes may be specified: ReadWriteOnce, ReadOnlyMany, ReadWriteMany.
+// +kubebuilder:validation:Enum=ReadWriteOnce;ReadOnlyMany;ReadWriteMany
+type VolumeAccessMode string
+
+const (
+	ReadWriteOnceVolumeAccessMode VolumeAccessMode = "ReadWriteOnce"
+	ReadOnlyManyVolumeAccessMode  VolumeAccessMode = "ReadOnlyMany"
+	ReadWriteManyVolumeAccessMode VolumeAccessMode = "ReadWriteMany"
+)
+
+// VolumeSharing describes which workspaces use the volume.
+// Only one of the following sharing models may be specified: workspace, namespace.
+// +kubebuilder:validation:Enum=workspace;namespace
+type VolumeSharing string
+
+const (
+	// The volume is only used by the workspace that declares it
+	WorkspaceVolumeSharing VolumeSharing = "workspace"
+	// The volume is shared by all the workspaces of the namespace that declare a volume with the same name
+	NamespaceVolumeSharing VolumeSharing = "namespace"
+)
+
 // Volume that should be mounted to a component container
 type Volume struct {
+	// Size of the volume, expressed as a Kubernetes resource quantity, such as `1Gi`.
+	//
+	// When a parent or plugin volume is overridden with a different size,
+	// the larger of the two sizes is used, so that an override cannot shrink a volume
+	// below the size its parent or plugin requires.
 	// +optional
-	// Size of the volume
+	// +kubebuilder:validation:Pattern=`^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$`
 	Size string `json:"size,omitempty"`
+
+	// Whether the volume is ephemeral: its content is lost when the workspace stops.
+	// Ephemeral volumes are not persisted by a claim, so they cannot have a storage class
+	// or an access mode, and cannot be shared with other workspaces.
+	// Their size, if any, is the maximum size of their content.
+	//
+	// Default value is `false`
+	// +optional
+	Ephemeral bool `json:"ephemeral,omitempty"`
+
+	// Storage class of the persistent volume claim.
+	//
+	// Defaults to the default storage class of the cluster
+	// +optional
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// Access mode of the persistent volume claim:
+	//
+	// - `ReadWriteOnce` means that the volume can be mounted for reading and writing by a single node.
+	//
+	// - `ReadOnlyMany` means that the volume can be mounted for reading by many nodes.
+	//
+	// - `ReadWriteMany` means that the volume can be mounted for reading and writing by many nodes.
+	//
+	// Default value is `ReadWriteOnce`
+	// +optional
+	AccessMode VolumeAccessMode `json:"accessMode,omitempty"`
+
+	// Describes which workspaces use the volume:
+	//
+	// - `workspace` means that the volume is only used by the workspace that declares it.
+	//
+	// - `namespace` means that the volume is shared by all the workspaces of the namespace
+	// that declare a volume with the same name, for example to share a cache of dependencies.
+	// Such a volume is not removed with the workspaces.
+	//
+	// Default value is `workspace`
+	// +optional
+	Sharing VolumeSharing `json:"sharing,omitempty"`
 }
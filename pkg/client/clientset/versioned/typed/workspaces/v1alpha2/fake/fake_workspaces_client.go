@@ -0,0 +1,28 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha2 "github.com/devfile/api/pkg/client/clientset/versioned/typed/workspaces/v1alpha2"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeWorkspaceV1alpha2 struct {
+	*testing.Fake
+}
+
+func (c *FakeWorkspaceV1alpha2) DevWorkspaces(namespace string) v1alpha2.DevWorkspaceInterface {
+	return &FakeDevWorkspaces{c, namespace}
+}
+
+func (c *FakeWorkspaceV1alpha2) DevWorkspaceTemplates(namespace string) v1alpha2.DevWorkspaceTemplateInterface {
+	return &FakeDevWorkspaceTemplates{c, namespace}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeWorkspaceV1alpha2) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	time "time"
+
+	workspacesv1alpha2 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	versioned "github.com/devfile/api/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/devfile/api/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha2 "github.com/devfile/api/pkg/client/listers/workspaces/v1alpha2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// DevWorkspaceInformer provides access to a shared informer and lister for
+// DevWorkspaces.
+type DevWorkspaceInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha2.DevWorkspaceLister
+}
+
+type devWorkspaceInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewDevWorkspaceInformer constructs a new informer for DevWorkspace type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewDevWorkspaceInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredDevWorkspaceInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredDevWorkspaceInformer constructs a new informer for DevWorkspace type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredDevWorkspaceInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.WorkspaceV1alpha2().DevWorkspaces(namespace).List(options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.WorkspaceV1alpha2().DevWorkspaces(namespace).Watch(options)
+			},
+		},
+		&workspacesv1alpha2.DevWorkspace{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *devWorkspaceInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredDevWorkspaceInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *devWorkspaceInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&workspacesv1alpha2.DevWorkspace{}, f.defaultInformer)
+}
+
+func (f *devWorkspaceInformer) Lister() v1alpha2.DevWorkspaceLister {
+	return v1alpha2.NewDevWorkspaceLister(f.Informer().GetIndexer())
+}
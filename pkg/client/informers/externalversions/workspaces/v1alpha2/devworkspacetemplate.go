@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	time "time"
+
+	workspacesv1alpha2 "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	versioned "github.com/devfile/api/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/devfile/api/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha2 "github.com/devfile/api/pkg/client/listers/workspaces/v1alpha2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// DevWorkspaceTemplateInformer provides access to a shared informer and lister for
+// DevWorkspaceTemplates.
+type DevWorkspaceTemplateInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha2.DevWorkspaceTemplateLister
+}
+
+type devWorkspaceTemplateInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewDevWorkspaceTemplateInformer constructs a new informer for DevWorkspaceTemplate type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewDevWorkspaceTemplateInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredDevWorkspaceTemplateInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredDevWorkspaceTemplateInformer constructs a new informer for DevWorkspaceTemplate type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredDevWorkspaceTemplateInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.WorkspaceV1alpha2().DevWorkspaceTemplates(namespace).List(options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.WorkspaceV1alpha2().DevWorkspaceTemplates(namespace).Watch(options)
+			},
+		},
+		&workspacesv1alpha2.DevWorkspaceTemplate{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *devWorkspaceTemplateInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredDevWorkspaceTemplateInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *devWorkspaceTemplateInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&workspacesv1alpha2.DevWorkspaceTemplate{}, f.defaultInformer)
+}
+
+func (f *devWorkspaceTemplateInformer) Lister() v1alpha2.DevWorkspaceTemplateLister {
+	return v1alpha2.NewDevWorkspaceTemplateLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,36 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	internalinterfaces "github.com/devfile/api/pkg/client/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// DevWorkspaces returns a DevWorkspaceInformer.
+	DevWorkspaces() DevWorkspaceInformer
+	// DevWorkspaceTemplates returns a DevWorkspaceTemplateInformer.
+	DevWorkspaceTemplates() DevWorkspaceTemplateInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// DevWorkspaces returns a DevWorkspaceInformer.
+func (v *version) DevWorkspaces() DevWorkspaceInformer {
+	return &devWorkspaceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DevWorkspaceTemplates returns a DevWorkspaceTemplateInformer.
+func (v *version) DevWorkspaceTemplates() DevWorkspaceTemplateInformer {
+	return &devWorkspaceTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,105 @@
+package compose
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	content, err := ioutil.ReadFile(filepath.Join("test-fixtures", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func readDevfile(t *testing.T, name string) *workspaces.DevWorkspaceTemplateSpecContent {
+	content := &workspaces.DevWorkspaceTemplateSpecContent{}
+	if err := yaml.Unmarshal(readFixture(t, name), content); err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestExport(t *testing.T) {
+	project, warnings, err := Export(readDevfile(t, "export/devfile.yaml"), ExportOptions{ProjectsRoot: "./src"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	marshaled, err := project.Marshal()
+	if assert.NoError(t, err) {
+		assert.Equal(t, string(readFixture(t, "export/docker-compose.yaml")), string(marshaled))
+	}
+	assert.Equal(t, strings.Split(strings.TrimSpace(string(readFixture(t, "export/warnings.txt"))), "\n"), warnings)
+}
+
+func TestExportUnknownVolume(t *testing.T) {
+	content := readDevfile(t, "export/devfile.yaml")
+	content.Components[0].Container.VolumeMounts[0].Name = "missing"
+	_, _, err := Export(content, ExportOptions{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "container 'nodejs' mounts volume 'missing', which is not a volume component")
+	}
+}
+
+func TestImport(t *testing.T) {
+	for _, fixture := range []string{"import", "import-names"} {
+		t.Run(fixture, func(t *testing.T) {
+			project, err := Parse(readFixture(t, filepath.Join(fixture, "docker-compose.yaml")))
+			if !assert.NoError(t, err) {
+				return
+			}
+			content, warnings, err := Import(project, ImportOptions{ProjectsRoot: "./src"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, readDevfile(t, filepath.Join(fixture, "devfile.yaml")), content)
+			assert.Equal(t, strings.Split(strings.TrimSpace(string(readFixture(t, filepath.Join(fixture, "warnings.txt")))), "\n"), warnings)
+		})
+	}
+}
+
+func TestImportDuplicateNames(t *testing.T) {
+	project, err := Parse([]byte(`
+services:
+  data:
+    image: postgres:13
+    volumes:
+      - data:/var/lib/postgresql/data
+`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, _, err = Import(project, ImportOptions{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Components have duplicate keys: data")
+	}
+}
+
+func TestImportDependencyCycle(t *testing.T) {
+	project, err := Parse([]byte(`
+services:
+  first:
+    image: busybox
+    depends_on:
+      second:
+        condition: service_completed_successfully
+  second:
+    image: busybox
+    depends_on:
+      - first
+`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, _, err = Import(project, ImportOptions{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "services have a dependency cycle: first -> second -> first")
+	}
+}
@@ -0,0 +1,275 @@
+package compose
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/images"
+	"github.com/devfile/api/pkg/utils/validation"
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ExportOptions are the options of the export of a devfile to docker-compose
+type ExportOptions struct {
+	// Directory of the host that contains the project sources, and is bind-mounted
+	// in the containers that mount sources.
+	// Relative paths are relative to the `docker-compose.yaml` file.
+	//
+	// Defaults to `.`
+	ProjectsRoot string
+}
+
+// Export converts a flattened devfile to a docker-compose project, so that the workspace
+// can run locally without a cluster:
+//
+// - containers become services, with the container `command` and `args` as the service `entrypoint` and `command`,
+//
+// - volume components become named volumes, and the volume mounts of the containers reference them.
+// Ephemeral volumes are `tmpfs` volumes,
+//
+// - containers that mount sources get a bind mount of the projects root at their source mapping,
+// as well as the `PROJECTS_ROOT` and `PROJECT_SOURCE` environment variables,
+//
+// - the containers that run in the main workspace pod share the network of the first of them,
+// so that they can reach each other on `localhost`, as in the pod,
+//
+// - endpoints that are exposed outside of the pod become port mappings on the host,
+//
+// - containers that run the image of an image component get the build of the image,
+// so that docker-compose builds it before starting them.
+//
+// Returns warnings for the elements that cannot be represented in docker-compose,
+// such as Kubernetes or OpenShift components.
+func Export(content *workspaces.DevWorkspaceTemplateSpecContent, options ExportOptions) (*Project, []string, error) {
+	projectsRoot := options.ProjectsRoot
+	if projectsRoot == "" {
+		projectsRoot = "."
+	}
+	projectSource := ""
+	if len(content.Projects) > 0 {
+		cloneDirectory, err := validation.CloneDirectory(content.Projects[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		projectSource = cloneDirectory
+	}
+
+	project := &Project{Services: map[string]Service{}}
+	var warnings []string
+	var errors *multierror.Error
+
+	volumes := map[string]bool{}
+	for _, component := range content.Components {
+		if component.Volume == nil {
+			continue
+		}
+		volumes[component.Name] = true
+		if project.Volumes == nil {
+			project.Volumes = map[string]Volume{}
+		}
+		volume, volumeWarnings, err := composeVolume(component.Name, component.Volume.Volume)
+		if err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+		project.Volumes[component.Name] = volume
+		warnings = append(warnings, volumeWarnings...)
+	}
+
+	podNetworkOwner := ""
+	builtImages := map[string]bool{}
+	for _, component := range content.Components {
+		switch {
+		case component.Container != nil:
+			container := component.Container
+			service, err := containerService(component.Name, container.Container, volumes, projectsRoot, projectSource)
+			if err != nil {
+				errors = multierror.Append(errors, err)
+				continue
+			}
+			for _, env := range container.Env {
+				if env.ValueFrom != nil {
+					warnings = append(warnings, fmt.Sprintf("environment variable '%s' of container '%s' is ignored, since docker-compose cannot read values from Secrets, ConfigMaps or the workspace metadata", env.Name, component.Name))
+				}
+			}
+			if imageName, build := images.ContainerImage(content.Components, container.Container); build != nil {
+				service.Image = imageName
+				service.Build = serviceBuild(*build, projectsRoot, projectSource)
+				builtImages[build.Component] = true
+			}
+			portsOwner := component.Name
+			if !container.DedicatedPod {
+				if podNetworkOwner == "" {
+					podNetworkOwner = component.Name
+				} else {
+					service.NetworkMode = "service:" + podNetworkOwner
+					portsOwner = podNetworkOwner
+				}
+			}
+			project.Services[component.Name] = service
+			owner := project.Services[portsOwner]
+			owner.Ports = appendPorts(owner.Ports, container.Endpoints)
+			project.Services[portsOwner] = owner
+		case component.Kubernetes != nil:
+			warnings = append(warnings, fmt.Sprintf("component '%s' is ignored, since Kubernetes components cannot be represented in docker-compose", component.Name))
+		case component.Openshift != nil:
+			warnings = append(warnings, fmt.Sprintf("component '%s' is ignored, since OpenShift components cannot be represented in docker-compose", component.Name))
+		case component.Custom != nil:
+			warnings = append(warnings, fmt.Sprintf("component '%s' is ignored, since custom components of class '%s' cannot be represented in docker-compose", component.Name, component.Custom.ComponentClass))
+		case component.Plugin != nil:
+			warnings = append(warnings, fmt.Sprintf("plugin '%s' is ignored, since the devfile should be flattened before the export", component.Name))
+		}
+	}
+
+	for _, build := range images.Builds(content.Components) {
+		if !builtImages[build.Component] {
+			warnings = append(warnings, fmt.Sprintf("image component '%s' is ignored, since no container runs its image", build.Component))
+		}
+	}
+
+	if err := errors.ErrorOrNil(); err != nil {
+		return nil, nil, err
+	}
+	return project, warnings, nil
+}
+
+// serviceBuild returns the build of an image component, whose build context is relative to the project source
+func serviceBuild(build images.Build, projectsRoot string, projectSource string) *Build {
+	serviceBuild := &Build{
+		Context:    path.Join(projectsRoot, projectSource, build.BuildContext),
+		Dockerfile: build.Dockerfile,
+	}
+	if len(build.Args) > 0 {
+		serviceBuild.Args = Environment{}
+		for _, arg := range build.Args {
+			serviceBuild.Args.set(arg.Name, arg.Value)
+		}
+	}
+	return serviceBuild
+}
+
+func containerService(name string, container workspaces.Container, volumes map[string]bool, projectsRoot string, projectSource string) (Service, error) {
+	service := Service{
+		Image:      container.Image,
+		Entrypoint: container.Command,
+		Command:    container.Args,
+	}
+	environment := Environment{}
+
+	if container.MountSources {
+		sourceMapping := container.SourceMapping
+		if sourceMapping == "" {
+			sourceMapping = validation.DefaultSourceMapping
+		}
+		service.Volumes = append(service.Volumes, ServiceVolume(projectsRoot+":"+sourceMapping))
+		environment.set("PROJECTS_ROOT", sourceMapping)
+		if projectSource != "" {
+			environment.set("PROJECT_SOURCE", path.Join(sourceMapping, projectSource))
+		}
+	}
+
+	for _, mount := range container.VolumeMounts {
+		if !volumes[mount.Name] {
+			return service, fmt.Errorf("container '%s' mounts volume '%s', which is not a volume component", name, mount.Name)
+		}
+		mountPath := mount.Path
+		if mountPath == "" {
+			mountPath = "/" + mount.Name
+		}
+		service.Volumes = append(service.Volumes, ServiceVolume(mount.Name+":"+mountPath))
+	}
+
+	for _, env := range container.Env {
+		if env.ValueFrom == nil {
+			environment.set(env.Name, env.Value)
+		}
+	}
+	if len(environment) > 0 {
+		service.Environment = environment
+	}
+
+	var err error
+	if service.MemLimit, err = memory(name, container.MemoryLimit); err != nil {
+		return service, err
+	}
+	if service.MemReservation, err = memory(name, container.MemoryRequest); err != nil {
+		return service, err
+	}
+	if container.CpuLimit != "" {
+		quantity, err := resource.ParseQuantity(container.CpuLimit)
+		if err != nil {
+			return service, fmt.Errorf("invalid CPU limit in container '%s': %v", name, err)
+		}
+		service.Cpus = Scalar(strconv.FormatFloat(float64(quantity.MilliValue())/1000, 'f', -1, 64))
+	}
+	return service, nil
+}
+
+// composeVolume converts a volume component to a named volume:
+// ephemeral volumes become `tmpfs` volumes, limited to the size of the volume,
+// and volumes shared with the namespace are not prefixed by the project name, so that other projects can use them.
+func composeVolume(name string, volume workspaces.Volume) (Volume, []string, error) {
+	var warnings []string
+	composeVolume := Volume{}
+	if volume.Sharing == workspaces.NamespaceVolumeSharing {
+		composeVolume.Name = name
+	}
+	if volume.StorageClass != "" {
+		warnings = append(warnings, fmt.Sprintf("the storage class of volume '%s' is ignored, since docker-compose volumes have no storage class", name))
+	}
+	if volume.AccessMode != "" {
+		warnings = append(warnings, fmt.Sprintf("the access mode of volume '%s' is ignored, since docker-compose volumes have no access mode", name))
+	}
+	if !volume.Ephemeral {
+		if volume.Size != "" {
+			warnings = append(warnings, fmt.Sprintf("the size of volume '%s' is ignored, since docker-compose volumes have no size", name))
+		}
+		return composeVolume, warnings, nil
+	}
+
+	composeVolume.Driver = "local"
+	composeVolume.DriverOpts = map[string]string{"type": "tmpfs", "device": "tmpfs"}
+	if volume.Size != "" {
+		quantity, err := resource.ParseQuantity(volume.Size)
+		if err != nil {
+			return composeVolume, nil, fmt.Errorf("invalid size '%s' of volume '%s': %v", volume.Size, name, err)
+		}
+		composeVolume.DriverOpts["o"] = "size=" + strconv.FormatInt(quantity.Value(), 10)
+	}
+	return composeVolume, warnings, nil
+}
+
+// memory converts a Kubernetes memory quantity to a number of bytes
+func memory(name string, value string) (Scalar, error) {
+	if value == "" {
+		return "", nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid memory quantity '%s' in container '%s': %v", value, name, err)
+	}
+	return Scalar(strconv.FormatInt(quantity.Value(), 10) + "b"), nil
+}
+
+func appendPorts(ports []Port, endpoints []workspaces.Endpoint) []Port {
+	for _, endpoint := range endpoints {
+		if endpoint.Exposure == workspaces.NoneEndpointExposure {
+			continue
+		}
+		port := Port(fmt.Sprintf("%d:%d", endpoint.TargetPort, endpoint.TargetPort))
+		if endpoint.Protocol == workspaces.UDPEndpointProtocol {
+			port += "/udp"
+		}
+		alreadyPublished := false
+		for _, existing := range ports {
+			alreadyPublished = alreadyPublished || existing == port
+		}
+		if !alreadyPublished {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
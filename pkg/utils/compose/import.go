@@ -0,0 +1,400 @@
+package compose
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/unions"
+	"github.com/devfile/api/pkg/utils/validation"
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ImportOptions are the options of the import of a docker-compose project as a devfile
+type ImportOptions struct {
+	// Directory of the host that contains the project sources.
+	// Services that bind-mount it get `mountSources` enabled, with the mount target as source mapping.
+	// Relative paths are relative to the `docker-compose.yaml` file.
+	//
+	// Defaults to `.`
+	ProjectsRoot string
+}
+
+// Import converts a docker-compose project to devfile content:
+//
+// - services become container components, with the service `entrypoint` and `command`
+// as the container `command` and `args`,
+//
+// - named and anonymous volumes become volume components, mounted in the containers,
+//
+// - the bind mount of the projects root becomes a source mount, and other bind mounts are ignored,
+//
+// - published ports become endpoints, and exposed ports become `internal` endpoints,
+//
+// - services that other services wait for with the `service_completed_successfully` condition
+// become `apply` commands run by a sequential composite command bound to the `preStart` event,
+// ordered according to their own dependencies,
+// since they are expected to run before the workspace start, as init containers.
+// Other dependencies have no equivalent, since all the containers of a workspace start together.
+//
+// The result is checked with union normalization and keys uniqueness.
+// Returns warnings for the elements that cannot be represented in a devfile.
+func Import(project *Project, options ImportOptions) (*workspaces.DevWorkspaceTemplateSpecContent, []string, error) {
+	projectsRoot := path.Clean(options.ProjectsRoot)
+	if options.ProjectsRoot == "" {
+		projectsRoot = "."
+	}
+
+	content := &workspaces.DevWorkspaceTemplateSpecContent{}
+	var warnings []string
+	var errors *multierror.Error
+
+	var serviceNames []string
+	for name := range project.Services {
+		serviceNames = append(serviceNames, name)
+	}
+	sort.Strings(serviceNames)
+
+	componentNames := map[string]string{}
+	for _, name := range serviceNames {
+		componentName, err := devfileName(name)
+		if err != nil {
+			errors = multierror.Append(errors, fmt.Errorf("service '%s' %v", name, err))
+			continue
+		}
+		if componentName != name {
+			warnings = append(warnings, fmt.Sprintf("service '%s' is imported as component '%s', since devfile names should be DNS-1123 labels", name, componentName))
+		}
+		componentNames[name] = componentName
+	}
+
+	// Compose names of the named volumes, by volume component name
+	volumes := map[string]string{}
+	for _, name := range serviceNames {
+		service := project.Services[name]
+		if service.Image == "" {
+			warnings = append(warnings, fmt.Sprintf("service '%s' is ignored, since it has no image", name))
+			continue
+		}
+		componentName, isValid := componentNames[name]
+		if !isValid {
+			continue
+		}
+		container, serviceWarnings, err := serviceContainer(name, componentName, service, projectsRoot, volumes)
+		warnings = append(warnings, serviceWarnings...)
+		if err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+		content.Components = append(content.Components, workspaces.Component{
+			Name:           componentName,
+			ComponentUnion: workspaces.ComponentUnion{Container: container},
+		})
+	}
+
+	var volumeNames []string
+	for name := range volumes {
+		volumeNames = append(volumeNames, name)
+	}
+	sort.Strings(volumeNames)
+	for _, name := range volumeNames {
+		if composeName := volumes[name]; composeName != "" && composeName != name {
+			warnings = append(warnings, fmt.Sprintf("volume '%s' is imported as component '%s', since devfile names should be DNS-1123 labels", composeName, name))
+		}
+		content.Components = append(content.Components, workspaces.Component{
+			Name:           name,
+			ComponentUnion: workspaces.ComponentUnion{Volume: &workspaces.VolumeComponent{}},
+		})
+	}
+
+	if err := importInitServices(project, serviceNames, componentNames, content); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	if err := errors.ErrorOrNil(); err != nil {
+		return nil, nil, err
+	}
+	if err := unions.Normalize(content); err != nil {
+		return nil, nil, err
+	}
+	if err := validation.ValidateKeys(content); err != nil {
+		return nil, nil, err
+	}
+	if err := unions.Simplify(content); err != nil {
+		return nil, nil, err
+	}
+	return content, warnings, nil
+}
+
+// serviceContainer converts a service to a container component named `componentName`,
+// and adds the volumes it mounts to `volumes`.
+func serviceContainer(name string, componentName string, service Service, projectsRoot string, volumes map[string]string) (*workspaces.ContainerComponent, []string, error) {
+	container := &workspaces.ContainerComponent{}
+	container.Image = service.Image
+	container.Command = service.Entrypoint
+	container.Args = service.Command
+	var warnings []string
+
+	for _, variable := range service.Environment.Names() {
+		value := service.Environment[variable]
+		if value == nil {
+			warnings = append(warnings, fmt.Sprintf("environment variable '%s' of service '%s' is ignored, since it inherits its value from the host", variable, name))
+			continue
+		}
+		container.Env = append(container.Env, workspaces.EnvVar{Name: variable, Value: *value})
+	}
+
+	for _, volume := range service.Volumes {
+		parts := strings.Split(string(volume), ":")
+		switch {
+		case len(parts) == 1:
+			volumeName := anonymousVolumeName(componentName, parts[0])
+			volumes[volumeName] = ""
+			container.VolumeMounts = append(container.VolumeMounts, workspaces.VolumeMount{Name: volumeName, Path: parts[0]})
+		case isHostPath(parts[0]):
+			if path.Clean(parts[0]) != projectsRoot {
+				warnings = append(warnings, fmt.Sprintf("bind mount of host path '%s' in service '%s' is ignored", parts[0], name))
+				continue
+			}
+			container.MountSources = true
+			if parts[1] != validation.DefaultSourceMapping {
+				container.SourceMapping = parts[1]
+			}
+		default:
+			volumeName, err := devfileName(parts[0])
+			if err != nil {
+				return nil, warnings, fmt.Errorf("volume '%s' of service '%s' %v", parts[0], name, err)
+			}
+			volumes[volumeName] = parts[0]
+			container.VolumeMounts = append(container.VolumeMounts, workspaces.VolumeMount{Name: volumeName, Path: parts[1]})
+		}
+	}
+
+	targetPorts := map[int]bool{}
+	for _, ports := range []struct {
+		list     []Port
+		exposure workspaces.EndpointExposure
+	}{{service.Ports, ""}, {service.Expose, workspaces.InternalEndpointExposure}} {
+		for _, port := range ports.list {
+			targetPort, protocol, err := parsePort(port)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("port '%s' of service '%s' is ignored: %v", port, name, err))
+				continue
+			}
+			if targetPorts[targetPort] {
+				continue
+			}
+			targetPorts[targetPort] = true
+			endpoint := workspaces.Endpoint{
+				Name:       withSuffix(componentName, fmt.Sprintf("-%d", targetPort)),
+				TargetPort: targetPort,
+				Exposure:   ports.exposure,
+			}
+			if protocol == "udp" {
+				endpoint.Protocol = workspaces.UDPEndpointProtocol
+			}
+			container.Endpoints = append(container.Endpoints, endpoint)
+		}
+	}
+
+	var err error
+	if container.MemoryLimit, err = memoryQuantity(name, service.MemLimit); err != nil {
+		return nil, warnings, err
+	}
+	if container.MemoryRequest, err = memoryQuantity(name, service.MemReservation); err != nil {
+		return nil, warnings, err
+	}
+	if service.Cpus != "" {
+		cpus, err := strconv.ParseFloat(string(service.Cpus), 64)
+		if err != nil {
+			return nil, warnings, fmt.Errorf("invalid number of CPUs '%s' in service '%s'", service.Cpus, name)
+		}
+		container.CpuLimit = resource.NewMilliQuantity(int64(cpus*1000), resource.DecimalSI).String()
+	}
+
+	if service.WorkingDir != "" {
+		warnings = append(warnings, fmt.Sprintf("the working directory of service '%s' is ignored, since containers have no working directory in a devfile", name))
+	}
+	if strings.HasPrefix(service.NetworkMode, "service:") || service.NetworkMode == "" {
+		return container, warnings, nil
+	}
+	warnings = append(warnings, fmt.Sprintf("network mode '%s' of service '%s' is ignored", service.NetworkMode, name))
+	return container, warnings, nil
+}
+
+func isHostPath(source string) bool {
+	return strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") || strings.HasPrefix(source, "~")
+}
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+// maxNameLength is the maximum length of a DNS-1123 label
+const maxNameLength = 63
+
+// devfileName turns a compose name, which may contain uppercase letters, underscores and dots,
+// into a DNS-1123 label, as devfile names should be
+func devfileName(name string) (string, error) {
+	sanitized := withSuffix(strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-"), "")
+	if sanitized == "" {
+		return "", fmt.Errorf("has no letter or digit, so it cannot be converted to a devfile name")
+	}
+	return sanitized, nil
+}
+
+// withSuffix appends the suffix to the name, truncating the name so that the result is not longer than a DNS-1123 label
+func withSuffix(name string, suffix string) string {
+	if len(name)+len(suffix) > maxNameLength {
+		name = strings.TrimRight(name[:maxNameLength-len(suffix)], "-")
+	}
+	return name + suffix
+}
+
+func anonymousVolumeName(component string, target string) string {
+	return withSuffix(component, "-"+strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(target), "-"), "-"))
+}
+
+// parsePort returns the container port and the protocol of a port mapping
+func parsePort(port Port) (int, string, error) {
+	mapping := string(port)
+	protocol := "tcp"
+	if i := strings.LastIndex(mapping, "/"); i >= 0 {
+		protocol = mapping[i+1:]
+		mapping = mapping[:i]
+	}
+	parts := strings.Split(mapping, ":")
+	target := parts[len(parts)-1]
+	if strings.Contains(target, "-") {
+		return 0, "", fmt.Errorf("port ranges are not supported")
+	}
+	number, err := strconv.Atoi(target)
+	if err != nil || number < 1 || number > 65535 {
+		return 0, "", fmt.Errorf("invalid port number '%s'", target)
+	}
+	return number, protocol, nil
+}
+
+var byteUnits = map[string]int64{
+	"":   1,
+	"b":  1,
+	"k":  1 << 10,
+	"kb": 1 << 10,
+	"m":  1 << 20,
+	"mb": 1 << 20,
+	"g":  1 << 30,
+	"gb": 1 << 30,
+}
+
+var byteSize = regexp.MustCompile(`^([0-9]+)([a-z]*)$`)
+
+// memoryQuantity converts a docker-compose byte size, such as `512m`, to a Kubernetes quantity
+func memoryQuantity(service string, size Scalar) (string, error) {
+	if size == "" {
+		return "", nil
+	}
+	match := byteSize.FindStringSubmatch(strings.ToLower(string(size)))
+	if match == nil {
+		return "", fmt.Errorf("invalid memory size '%s' in service '%s'", size, service)
+	}
+	unit, isKnown := byteUnits[match[2]]
+	if !isKnown {
+		return "", fmt.Errorf("invalid memory size '%s' in service '%s'", size, service)
+	}
+	number, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid memory size '%s' in service '%s'", size, service)
+	}
+	return resource.NewQuantity(number*unit, resource.BinarySI).String(), nil
+}
+
+// initServicesCommand is the id of the composite command that applies the init services in order
+const initServicesCommand = "init-services"
+
+// importInitServices adds an `apply` command for each service that other services wait to complete,
+// run in order by a sequential composite command bound to the `preStart` event, so that dependencies are applied first.
+func importInitServices(project *Project, serviceNames []string, componentNames map[string]string, content *workspaces.DevWorkspaceTemplateSpecContent) error {
+	initServices := map[string]bool{}
+	for _, name := range serviceNames {
+		for dependency, condition := range project.Services[name].DependsOn {
+			if condition.Condition == ServiceCompletedSuccessfullyCondition {
+				initServices[dependency] = true
+			}
+		}
+	}
+	if len(initServices) == 0 {
+		return nil
+	}
+
+	var ordered []string
+	visited := map[string]bool{}
+	visiting := map[string]bool{}
+	var visit func(name string, chain []string) error
+	visit = func(name string, chain []string) error {
+		if visiting[name] {
+			return fmt.Errorf("services have a dependency cycle: %s -> %s", strings.Join(chain, " -> "), name)
+		}
+		if visited[name] {
+			return nil
+		}
+		service, exists := project.Services[name]
+		if !exists {
+			return fmt.Errorf("service '%s' is a dependency of service '%s', but doesn't exist", name, chain[len(chain)-1])
+		}
+		visiting[name] = true
+		var dependencies []string
+		for dependency := range service.DependsOn {
+			dependencies = append(dependencies, dependency)
+		}
+		sort.Strings(dependencies)
+		for _, dependency := range dependencies {
+			if err := visit(dependency, append(chain, name)); err != nil {
+				return err
+			}
+		}
+		visiting[name] = false
+		visited[name] = true
+		if initServices[name] {
+			ordered = append(ordered, name)
+		}
+		return nil
+	}
+	for _, name := range serviceNames {
+		if err := visit(name, nil); err != nil {
+			return err
+		}
+	}
+
+	var ids []string
+	for _, name := range ordered {
+		component := componentNames[name]
+		id := withSuffix("apply-"+component, "")
+		ids = append(ids, id)
+		content.Commands = append(content.Commands, workspaces.Command{
+			Id: id,
+			CommandUnion: workspaces.CommandUnion{
+				Apply: &workspaces.ApplyCommand{Component: component},
+			},
+		})
+	}
+	// The order is kept by a sequential composite command, since the commands bound to an event
+	// are merged as a set with the ones of the parent and plugins
+	preStart := ids[0]
+	if len(ids) > 1 {
+		preStart = initServicesCommand
+		content.Commands = append(content.Commands, workspaces.Command{
+			Id: preStart,
+			CommandUnion: workspaces.CommandUnion{
+				Composite: &workspaces.CompositeCommand{Commands: ids},
+			},
+		})
+	}
+	if content.Events == nil {
+		content.Events = &workspaces.Events{}
+	}
+	content.Events.PreStart = append(content.Events.PreStart, preStart)
+	return nil
+}
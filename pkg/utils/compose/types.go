@@ -0,0 +1,309 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Project is the content of a `docker-compose.yaml` file.
+//
+// Only the elements that have an equivalent in a devfile are supported.
+type Project struct {
+	Version  string             `json:"version,omitempty"`
+	Services map[string]Service `json:"services"`
+	Volumes  map[string]Volume  `json:"volumes,omitempty"`
+}
+
+// Service is a service of a `docker-compose.yaml` file
+type Service struct {
+	Image       string          `json:"image,omitempty"`
+	Build       *Build          `json:"build,omitempty"`
+	Entrypoint  CommandLine     `json:"entrypoint,omitempty"`
+	Command     CommandLine     `json:"command,omitempty"`
+	WorkingDir  string          `json:"working_dir,omitempty"`
+	Environment Environment     `json:"environment,omitempty"`
+	Ports       []Port          `json:"ports,omitempty"`
+	Expose      []Port          `json:"expose,omitempty"`
+	Volumes     []ServiceVolume `json:"volumes,omitempty"`
+	DependsOn   DependsOn       `json:"depends_on,omitempty"`
+	// Network of another service that this service shares, in the `service:<name>` form
+	NetworkMode    string `json:"network_mode,omitempty"`
+	MemLimit       Scalar `json:"mem_limit,omitempty"`
+	MemReservation Scalar `json:"mem_reservation,omitempty"`
+	Cpus           Scalar `json:"cpus,omitempty"`
+}
+
+// Volume is a named volume of a `docker-compose.yaml` file
+type Volume struct {
+	Name       string            `json:"name,omitempty"`
+	Driver     string            `json:"driver,omitempty"`
+	DriverOpts map[string]string `json:"driver_opts,omitempty"`
+}
+
+// Build is the build of the image of a service.
+// It may be written either as the path of the build context, or as an object.
+type Build struct {
+	Context    string      `json:"context,omitempty"`
+	Dockerfile string      `json:"dockerfile,omitempty"`
+	Args       Environment `json:"args,omitempty"`
+}
+
+// UnmarshalJSON accepts both the string and the object forms of the build.
+func (b *Build) UnmarshalJSON(data []byte) error {
+	var context string
+	if err := json.Unmarshal(data, &context); err == nil {
+		*b = Build{Context: context}
+		return nil
+	}
+	type build Build
+	return json.Unmarshal(data, (*build)(b))
+}
+
+// Marshal returns the YAML content of the `docker-compose.yaml` file.
+func (p *Project) Marshal() ([]byte, error) {
+	return yaml.Marshal(p)
+}
+
+// Parse parses the YAML content of a `docker-compose.yaml` file.
+func Parse(content []byte) (*Project, error) {
+	project := &Project{}
+	if err := yaml.Unmarshal(content, project); err != nil {
+		return nil, fmt.Errorf("failed to parse the docker-compose file: %v", err)
+	}
+	return project, nil
+}
+
+// Scalar is a string that may also be written as a number, such as a memory size or a number of CPUs.
+type Scalar string
+
+// UnmarshalJSON accepts both strings and numbers.
+func (s *Scalar) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value != nil {
+		*s = Scalar(fmt.Sprint(value))
+	}
+	return nil
+}
+
+// CommandLine is a command, with its arguments.
+// It may be written either as a list, or as a single string that is split as a shell would do.
+type CommandLine []string
+
+// UnmarshalJSON accepts both the list and the string forms of a command.
+func (c *CommandLine) UnmarshalJSON(data []byte) error {
+	var line string
+	if err := json.Unmarshal(data, &line); err == nil {
+		words, err := splitWords(line)
+		if err != nil {
+			return err
+		}
+		*c = words
+		return nil
+	}
+	var words []string
+	if err := json.Unmarshal(data, &words); err != nil {
+		return err
+	}
+	*c = words
+	return nil
+}
+
+// splitWords splits a command line into words, following the shell quoting rules.
+func splitWords(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, c := range line {
+		switch {
+		case escaped:
+			word.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quoting in command '%s'", line)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// Environment contains the environment variables of a service.
+// It may be written either as a map, or as a list of `NAME=value` strings.
+// Variables without a value, such as `NAME` in the list form or `NAME:` in the map form,
+// inherit their value from the host and are mapped to nil.
+type Environment map[string]*string
+
+// UnmarshalJSON accepts both the map and the list forms of the environment.
+func (e *Environment) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*e = Environment{}
+		for _, variable := range list {
+			parts := strings.SplitN(variable, "=", 2)
+			if len(parts) == 1 {
+				(*e)[parts[0]] = nil
+				continue
+			}
+			(*e)[parts[0]] = &parts[1]
+		}
+		return nil
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*e = Environment{}
+	for name, value := range values {
+		if value == nil {
+			(*e)[name] = nil
+			continue
+		}
+		text := fmt.Sprint(value)
+		(*e)[name] = &text
+	}
+	return nil
+}
+
+// set sets the value of an environment variable.
+func (e Environment) set(name string, value string) {
+	e[name] = &value
+}
+
+// Names returns the names of the environment variables, sorted.
+func (e Environment) Names() []string {
+	var names []string
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Port is a port mapping, in the `[[host_ip:]published:]target[/protocol]` short form.
+// The long form, as well as a single port number, are converted to the short form when parsed.
+type Port string
+
+// UnmarshalJSON accepts the short form, the long form and port numbers.
+func (p *Port) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch typed := value.(type) {
+	case string:
+		*p = Port(typed)
+	case float64:
+		*p = Port(fmt.Sprint(typed))
+	case map[string]interface{}:
+		port := fmt.Sprint(typed["target"])
+		if published, isSet := typed["published"]; isSet {
+			port = fmt.Sprint(published) + ":" + port
+			if hostIP, isSet := typed["host_ip"]; isSet {
+				port = fmt.Sprint(hostIP) + ":" + port
+			}
+		}
+		if protocol, isSet := typed["protocol"]; isSet {
+			port += "/" + fmt.Sprint(protocol)
+		}
+		*p = Port(port)
+	default:
+		return fmt.Errorf("invalid port: %s", string(data))
+	}
+	return nil
+}
+
+// ServiceVolume is a volume mount of a service, in the `[source:]target[:mode]` short form.
+// The long form is converted to the short form when parsed.
+type ServiceVolume string
+
+// UnmarshalJSON accepts both the short and the long forms.
+func (v *ServiceVolume) UnmarshalJSON(data []byte) error {
+	var short string
+	if err := json.Unmarshal(data, &short); err == nil {
+		*v = ServiceVolume(short)
+		return nil
+	}
+	var long struct {
+		Source   string `json:"source"`
+		Target   string `json:"target"`
+		ReadOnly bool   `json:"read_only"`
+	}
+	if err := json.Unmarshal(data, &long); err != nil {
+		return err
+	}
+	volume := long.Target
+	if long.Source != "" {
+		volume = long.Source + ":" + volume
+	}
+	if long.ReadOnly {
+		volume += ":ro"
+	}
+	*v = ServiceVolume(volume)
+	return nil
+}
+
+// Dependency conditions of `depends_on`
+const (
+	ServiceStartedCondition               = "service_started"
+	ServiceHealthyCondition               = "service_healthy"
+	ServiceCompletedSuccessfullyCondition = "service_completed_successfully"
+)
+
+// Dependency is a dependency of a service on another service
+type Dependency struct {
+	Condition string `json:"condition,omitempty"`
+}
+
+// DependsOn contains the dependencies of a service, by service name.
+// It may be written either as a map, or as a list of service names.
+type DependsOn map[string]Dependency
+
+// UnmarshalJSON accepts both the map and the list forms of the dependencies.
+func (d *DependsOn) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*d = DependsOn{}
+		for _, service := range list {
+			(*d)[service] = Dependency{Condition: ServiceStartedCondition}
+		}
+		return nil
+	}
+	var dependencies map[string]Dependency
+	if err := json.Unmarshal(data, &dependencies); err != nil {
+		return err
+	}
+	*d = dependencies
+	return nil
+}
@@ -0,0 +1,45 @@
+package conditions
+
+import (
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetCondition returns the condition of the given type in the workspace status,
+// or nil if the status doesn't contain such a condition.
+func GetCondition(status *workspaces.DevWorkspaceStatus, conditionType workspaces.WorkspaceConditionType) *workspaces.WorkspaceCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds the condition to the workspace status, or updates the existing condition
+// of the same type.
+//
+// The `LastTransitionTime` of an existing condition is only updated when its status changes.
+// When the `LastTransitionTime` of the condition passed in argument is not set, the current time is used.
+func SetCondition(status *workspaces.DevWorkspaceStatus, condition workspaces.WorkspaceCondition) {
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+	existing := GetCondition(status, condition.Type)
+	if existing == nil {
+		status.Conditions = append(status.Conditions, condition)
+		return
+	}
+	if existing.Status == condition.Status {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	}
+	*existing = condition
+}
+
+// IsConditionTrue returns true if the workspace status contains a condition of the given type
+// whose status is `True`.
+func IsConditionTrue(status *workspaces.DevWorkspaceStatus, conditionType workspaces.WorkspaceConditionType) bool {
+	condition := GetCondition(status, conditionType)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
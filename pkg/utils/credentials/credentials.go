@@ -0,0 +1,190 @@
+// Package credentials resolves the credentials referenced in devfiles,
+// used to access private git repositories and devfile registries,
+// and redacts them from logs and error messages.
+package credentials
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// TokenKey is the key of the token in secrets that are neither `kubernetes.io/ssh-auth` nor `kubernetes.io/basic-auth` secrets
+	TokenKey = "token"
+
+	// KnownHostsKey is the key of the accepted SSH host keys in `kubernetes.io/ssh-auth` secrets
+	KnownHostsKey = "known_hosts"
+
+	// Redacted replaces secret values in logs and error messages
+	Redacted = "*****"
+)
+
+// Credentials used to access a private location
+type Credentials struct {
+	// Username used with a password or a token
+	Username string
+
+	// Password used for HTTP basic authentication
+	Password string
+
+	// Token used as a bearer token over HTTP, or as a password for git HTTP remotes
+	Token string
+
+	// SSH private key, in PEM format
+	SSHPrivateKey []byte
+
+	// Accepted SSH host keys, in the `known_hosts` format.
+	// When empty, unknown host keys are accepted on first use.
+	KnownHosts []byte
+}
+
+// BasicAuth returns the base64 encoded value of an HTTP basic authentication header
+func BasicAuth(username string, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
+
+// secrets returns the secret values of the credentials,
+// including the basic authentication values built from the password or the token
+func (c *Credentials) secrets() []string {
+	secrets := []string{}
+	for _, secret := range []string{c.Password, c.Token, string(c.SSHPrivateKey)} {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	for _, secret := range []string{c.Password, c.Token} {
+		if secret != "" {
+			secrets = append(secrets, BasicAuth(c.Username, secret))
+		}
+	}
+	return secrets
+}
+
+// Redact replaces, in the given text, the secret values of the credentials,
+// as well as the passwords of the urls it contains.
+func (c *Credentials) Redact(text string) string {
+	if c != nil {
+		for _, secret := range c.secrets() {
+			text = strings.ReplaceAll(text, secret, Redacted)
+		}
+	}
+	return RedactURLs(text)
+}
+
+// RedactError returns an error whose message is the redacted message of `err`
+func (c *Credentials) RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	redacted := c.Redact(err.Error())
+	if redacted == err.Error() {
+		return err
+	}
+	return fmt.Errorf("%s", redacted)
+}
+
+// RedactURL replaces the password of an url that contains user information
+func RedactURL(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.User == nil {
+		return uri
+	}
+	var userInfo string
+	if _, hasPassword := parsed.User.Password(); hasPassword {
+		userInfo = parsed.User.Username() + ":" + Redacted
+	} else if parsed.Scheme == "http" || parsed.Scheme == "https" {
+		// A single user information element in http urls is typically a token
+		userInfo = Redacted
+	} else {
+		return uri
+	}
+	parsed.User = nil
+	prefix := parsed.Scheme + "://"
+	return prefix + userInfo + "@" + strings.TrimPrefix(parsed.String(), prefix)
+}
+
+// RedactURLs replaces the passwords of all the urls found in the given text
+func RedactURLs(text string) string {
+	words := strings.Fields(text)
+	for _, word := range words {
+		trimmed := strings.Trim(word, "'\"(),;")
+		if strings.Contains(trimmed, "://") && strings.Contains(trimmed, "@") {
+			if redacted := RedactURL(trimmed); redacted != trimmed {
+				text = strings.ReplaceAll(text, trimmed, redacted)
+			}
+		}
+	}
+	return text
+}
+
+// Provider retrieves the credentials referenced in a devfile
+type Provider interface {
+	Credentials(reference workspaces.CredentialsReference) (*Credentials, error)
+}
+
+// ProviderFunc allows using an ordinary function as a `Provider`.
+type ProviderFunc func(reference workspaces.CredentialsReference) (*Credentials, error)
+
+// Credentials calls f(reference).
+func (f ProviderFunc) Credentials(reference workspaces.CredentialsReference) (*Credentials, error) {
+	return f(reference)
+}
+
+// SecretProvider is a `Provider` that reads credentials from Kubernetes secrets
+type SecretProvider struct {
+	// GetSecret returns the secret with the given name, in the namespace of the workspace
+	GetSecret func(name string) (*corev1.Secret, error)
+}
+
+// Credentials returns the credentials stored in the referenced secret
+func (p *SecretProvider) Credentials(reference workspaces.CredentialsReference) (*Credentials, error) {
+	secret, err := p.GetSecret(reference.SecretName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials secret '%s': %v", reference.SecretName, err)
+	}
+	return FromSecret(secret)
+}
+
+// FromSecret returns the credentials stored in a Kubernetes secret, according to its type:
+//
+// - `kubernetes.io/ssh-auth` secrets provide an SSH private key and optional known hosts,
+//
+// - `kubernetes.io/basic-auth` secrets provide a username and a password,
+//
+// - other secrets provide a token and an optional username.
+func FromSecret(secret *corev1.Secret) (*Credentials, error) {
+	value := func(key string) string {
+		if data, exists := secret.Data[key]; exists {
+			return string(data)
+		}
+		return secret.StringData[key]
+	}
+
+	credentials := &Credentials{}
+	switch secret.Type {
+	case corev1.SecretTypeSSHAuth:
+		credentials.SSHPrivateKey = []byte(value(corev1.SSHAuthPrivateKey))
+		credentials.KnownHosts = []byte(value(KnownHostsKey))
+		if len(credentials.SSHPrivateKey) == 0 {
+			return nil, fmt.Errorf("credentials secret '%s' has no '%s' key", secret.Name, corev1.SSHAuthPrivateKey)
+		}
+	case corev1.SecretTypeBasicAuth:
+		credentials.Username = value(corev1.BasicAuthUsernameKey)
+		credentials.Password = value(corev1.BasicAuthPasswordKey)
+		if credentials.Password == "" {
+			return nil, fmt.Errorf("credentials secret '%s' has no '%s' key", secret.Name, corev1.BasicAuthPasswordKey)
+		}
+	default:
+		credentials.Username = value(corev1.BasicAuthUsernameKey)
+		credentials.Token = value(TokenKey)
+		if credentials.Token == "" {
+			return nil, fmt.Errorf("credentials secret '%s' has no '%s' key", secret.Name, TokenKey)
+		}
+	}
+	return credentials, nil
+}
@@ -0,0 +1,263 @@
+// Package custom allows integrators to provide the logic of the `Custom` components, commands and project sources
+// of a devfile, by registering a handler for each class of custom element they support.
+package custom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ElementKind is the kind of devfile element that can be customized
+type ElementKind string
+
+const (
+	// ComponentElementKind is the kind of `Custom` components, whose class is the `componentClass`
+	ComponentElementKind ElementKind = "component"
+	// CommandElementKind is the kind of `Custom` commands, whose class is the `commandClass`
+	CommandElementKind ElementKind = "command"
+	// ProjectSourceElementKind is the kind of `Custom` project sources, whose class is the `projectSourceClass`
+	ProjectSourceElementKind ElementKind = "project source"
+)
+
+// Element is a custom element found in a workspace template
+type Element struct {
+	// Kind of the element
+	Kind ElementKind
+
+	// Name of the component or project, or id of the command
+	Name string
+
+	// Class of the element
+	Class string
+
+	// Raw embedded resource of the element
+	Raw runtime.RawExtension
+
+	// Configuration of the element, as returned by the `Decode` function of the class handler.
+	// It is nil for elements whose class is unknown, in lenient mode.
+	Config interface{}
+}
+
+// Rendering contains what the handlers of custom elements contribute to the workspace
+type Rendering struct {
+	// Kubernetes objects that should be created with the workspace
+	Objects []runtime.Object
+
+	// Additional elements that should be added to the main workspace pod
+	PodAdditions workspaces.WorkspacePodContributions
+}
+
+// Handler provides the logic of a class of custom elements.
+type Handler struct {
+	// Decode turns the raw embedded resource of an element into its configuration.
+	// When nil, the embedded resource is decoded as a generic `map[string]interface{}`.
+	Decode func(raw []byte) (interface{}, error)
+
+	// Validate checks the decoded element.
+	Validate func(element *Element) error
+
+	// Render returns what the element contributes to the workspace.
+	Render func(element *Element) (*Rendering, error)
+}
+
+// JSONDecoder returns a `Decode` function that decodes the embedded resource
+// into the value returned by `newConfig`, which should be a pointer.
+// Fields of the embedded resource that are unknown in the configuration type are rejected.
+func JSONDecoder(newConfig func() interface{}) func(raw []byte) (interface{}, error) {
+	return func(raw []byte) (interface{}, error) {
+		config := newConfig()
+		if len(raw) == 0 {
+			return config, nil
+		}
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(config); err != nil {
+			return nil, err
+		}
+		return config, nil
+	}
+}
+
+func decodeGeneric(raw []byte) (interface{}, error) {
+	config := map[string]interface{}{}
+	if len(raw) == 0 {
+		return config, nil
+	}
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Registry contains the handlers of the supported custom element classes.
+type Registry struct {
+	// When `Lenient` is true, elements whose class has no registered handler
+	// are passed through without being decoded, validated or rendered, instead of producing an error.
+	Lenient bool
+
+	handlers map[ElementKind]map[string]Handler
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		handlers: map[ElementKind]map[string]Handler{},
+	}
+}
+
+// Register adds the handler of a class of custom elements of the given kind.
+// It is not allowed to register the same class twice for the same kind of element.
+func (r *Registry) Register(kind ElementKind, class string, handler Handler) error {
+	if class == "" {
+		return fmt.Errorf("custom %s class should not be empty", kind)
+	}
+	handlers, exists := r.handlers[kind]
+	if !exists {
+		handlers = map[string]Handler{}
+		r.handlers[kind] = handlers
+	}
+	if _, exists := handlers[class]; exists {
+		return fmt.Errorf("custom %s class '%s' is already registered", kind, class)
+	}
+	handlers[class] = handler
+	return nil
+}
+
+// Classes returns the sorted list of classes registered for the given kind of element
+func (r *Registry) Classes(kind ElementKind) []string {
+	classes := []string{}
+	for class := range r.handlers[kind] {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	return classes
+}
+
+// CollectElements returns the custom components, commands, projects and starter projects
+// of a workspace template, in that order, without decoding them.
+func CollectElements(content *workspaces.DevWorkspaceTemplateSpecContent) []*Element {
+	elements := []*Element{}
+	for _, component := range content.Components {
+		if component.Custom != nil {
+			elements = append(elements, &Element{
+				Kind:  ComponentElementKind,
+				Name:  component.Name,
+				Class: component.Custom.ComponentClass,
+				Raw:   component.Custom.EmbeddedResource,
+			})
+		}
+	}
+	for _, command := range content.Commands {
+		if command.Custom != nil {
+			elements = append(elements, &Element{
+				Kind:  CommandElementKind,
+				Name:  command.Id,
+				Class: command.Custom.CommandClass,
+				Raw:   command.Custom.EmbeddedResource,
+			})
+		}
+	}
+	projects := []workspaces.Project{}
+	projects = append(projects, content.Projects...)
+	for _, starterProject := range content.StarterProjects {
+		projects = append(projects, starterProject.Project)
+	}
+	for _, project := range projects {
+		if project.Custom != nil {
+			elements = append(elements, &Element{
+				Kind:  ProjectSourceElementKind,
+				Name:  project.Name,
+				Class: project.Custom.ProjectSourceClass,
+				Raw:   project.Custom.EmbeddedResource,
+			})
+		}
+	}
+	return elements
+}
+
+// Decode collects the custom elements of a workspace template, and decodes and validates
+// them with the handler of their class.
+//
+// Returns a non-nil error, that aggregates all the decoding and validation errors,
+// if an element could not be decoded or is invalid, or if the class of an element is unknown
+// and the registry is not lenient.
+func (r *Registry) Decode(content *workspaces.DevWorkspaceTemplateSpecContent) ([]*Element, error) {
+	var errors *multierror.Error
+	elements := CollectElements(content)
+	for _, element := range elements {
+		handler, known := r.handlers[element.Kind][element.Class]
+		if !known {
+			if !r.Lenient {
+				errors = multierror.Append(errors, fmt.Errorf("%s '%s' has an unknown custom class '%s'", element.Kind, element.Name, element.Class))
+			}
+			continue
+		}
+
+		decode := handler.Decode
+		if decode == nil {
+			decode = decodeGeneric
+		}
+		config, err := decode(element.Raw.Raw)
+		if err != nil {
+			errors = multierror.Append(errors, fmt.Errorf("failed to decode %s '%s' of custom class '%s': %v", element.Kind, element.Name, element.Class, err))
+			continue
+		}
+		element.Config = config
+
+		if handler.Validate != nil {
+			if err := handler.Validate(element); err != nil {
+				errors = multierror.Append(errors, fmt.Errorf("invalid %s '%s' of custom class '%s': %v", element.Kind, element.Name, element.Class, err))
+			}
+		}
+	}
+	if errors != nil {
+		return nil, errors
+	}
+	return elements, nil
+}
+
+// Render decodes and validates the custom elements of a workspace template,
+// and aggregates what their handlers contribute to the workspace.
+//
+// Elements whose class is unknown, in lenient mode, and elements whose handler has no `Render` function,
+// don't contribute anything.
+func (r *Registry) Render(content *workspaces.DevWorkspaceTemplateSpecContent) (*Rendering, error) {
+	elements, err := r.Decode(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var errors *multierror.Error
+	result := &Rendering{}
+	for _, element := range elements {
+		handler, known := r.handlers[element.Kind][element.Class]
+		if !known || handler.Render == nil {
+			continue
+		}
+		rendering, err := handler.Render(element)
+		if err != nil {
+			errors = multierror.Append(errors, fmt.Errorf("failed to render %s '%s' of custom class '%s': %v", element.Kind, element.Name, element.Class, err))
+			continue
+		}
+		if rendering == nil {
+			continue
+		}
+		result.Objects = append(result.Objects, rendering.Objects...)
+		additions := rendering.PodAdditions
+		result.PodAdditions.Volumes = append(result.PodAdditions.Volumes, additions.Volumes...)
+		result.PodAdditions.InitContainers = append(result.PodAdditions.InitContainers, additions.InitContainers...)
+		result.PodAdditions.Containers = append(result.PodAdditions.Containers, additions.Containers...)
+		result.PodAdditions.ImagePullSecrets = append(result.PodAdditions.ImagePullSecrets, additions.ImagePullSecrets...)
+		result.PodAdditions.CommonEnv = append(result.PodAdditions.CommonEnv, additions.CommonEnv...)
+	}
+	if errors != nil {
+		return nil, errors
+	}
+	return result, nil
+}
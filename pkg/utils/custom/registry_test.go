@@ -0,0 +1,162 @@
+package custom
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+type newComponentTypeConfig struct {
+	Config1 string            `json:"config1"`
+	Config2 map[string]string `json:"config2"`
+}
+
+type customSourceConfig struct {
+	CustomInfo string `json:"custom-info"`
+}
+
+func readCustomSample(t *testing.T) *workspaces.DevWorkspace {
+	content, err := ioutil.ReadFile("../../../samples/devworkspaces/custom.devworkspace.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspace := &workspaces.DevWorkspace{}
+	if err := yaml.Unmarshal(content, workspace); err != nil {
+		t.Fatal(err)
+	}
+	return workspace
+}
+
+func testRegistry(t *testing.T) *Registry {
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(ComponentElementKind, "NewComponentType", Handler{
+		Decode: JSONDecoder(func() interface{} { return &newComponentTypeConfig{} }),
+		Render: func(element *Element) (*Rendering, error) {
+			config := element.Config.(*newComponentTypeConfig)
+			return &Rendering{
+				Objects: []runtime.Object{
+					&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{Name: element.Name},
+						Data:       config.Config2,
+					},
+				},
+			}, nil
+		},
+	}))
+	assert.NoError(t, registry.Register(CommandElementKind, "myCommandType", Handler{
+		Validate: func(element *Element) error {
+			if _, exists := element.Config.(map[string]interface{})["anyCustomConfigValue"]; !exists {
+				return errors.New("'anyCustomConfigValue' is required")
+			}
+			return nil
+		},
+	}))
+	assert.NoError(t, registry.Register(ProjectSourceElementKind, "custom-source", Handler{
+		Decode: JSONDecoder(func() interface{} { return &customSourceConfig{} }),
+		Validate: func(element *Element) error {
+			if element.Config.(*customSourceConfig).CustomInfo == "" {
+				return errors.New("'custom-info' should not be empty")
+			}
+			return nil
+		},
+		Render: func(element *Element) (*Rendering, error) {
+			return &Rendering{
+				PodAdditions: workspaces.WorkspacePodContributions{
+					InitContainers: []corev1.Container{
+						{
+							Name:  "fetch-" + element.Name,
+							Image: "quay.io/example/custom-source-fetcher",
+							Args:  []string{element.Config.(*customSourceConfig).CustomInfo},
+						},
+					},
+				},
+			}, nil
+		},
+	}))
+	return registry
+}
+
+func TestRenderCustomSample(t *testing.T) {
+	workspace := readCustomSample(t)
+
+	rendering, err := testRegistry(t).Render(&workspace.Spec.Template.DevWorkspaceTemplateSpecContent)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, rendering.Objects, 1) {
+		configMap := rendering.Objects[0].(*corev1.ConfigMap)
+		assert.Equal(t, "myNewComponent", configMap.Name)
+		assert.Equal(t, map[string]string{"config2-1": "", "config2-2": ""}, configMap.Data)
+	}
+	if assert.Len(t, rendering.PodAdditions.InitContainers, 1) {
+		assert.Equal(t, "fetch-my-project", rendering.PodAdditions.InitContainers[0].Name)
+		assert.Equal(t, []string{"connexion-information"}, rendering.PodAdditions.InitContainers[0].Args)
+	}
+}
+
+func TestDecodeCustomSample(t *testing.T) {
+	workspace := readCustomSample(t)
+
+	elements, err := testRegistry(t).Decode(&workspace.Spec.Template.DevWorkspaceTemplateSpecContent)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.Len(t, elements, 3) {
+		assert.Equal(t, ComponentElementKind, elements[0].Kind)
+		assert.Equal(t, &newComponentTypeConfig{Config2: map[string]string{"config2-1": "", "config2-2": ""}}, elements[0].Config)
+		assert.Equal(t, CommandElementKind, elements[1].Kind)
+		assert.Equal(t, map[string]interface{}{"anyCustomConfigValue": ""}, elements[1].Config)
+		assert.Equal(t, ProjectSourceElementKind, elements[2].Kind)
+		assert.Equal(t, &customSourceConfig{CustomInfo: "connexion-information"}, elements[2].Config)
+	}
+}
+
+func TestUnknownCustomClasses(t *testing.T) {
+	workspace := readCustomSample(t)
+	registry := NewRegistry()
+
+	_, err := registry.Render(&workspace.Spec.Template.DevWorkspaceTemplateSpecContent)
+	assert.EqualError(t, err, "3 errors occurred:\n"+
+		"\t* component 'myNewComponent' has an unknown custom class 'NewComponentType'\n"+
+		"\t* command 'myCustomCommand' has an unknown custom class 'myCommandType'\n"+
+		"\t* project source 'my-project' has an unknown custom class 'custom-source'\n\n")
+
+	registry.Lenient = true
+	elements, err := registry.Decode(&workspace.Spec.Template.DevWorkspaceTemplateSpecContent)
+	assert.NoError(t, err)
+	if assert.Len(t, elements, 3) {
+		assert.Nil(t, elements[0].Config)
+		assert.NotEmpty(t, elements[0].Raw.Raw)
+	}
+	rendering, err := registry.Render(&workspace.Spec.Template.DevWorkspaceTemplateSpecContent)
+	assert.NoError(t, err)
+	assert.Empty(t, rendering.Objects)
+}
+
+func TestInvalidCustomElements(t *testing.T) {
+	workspace := readCustomSample(t)
+	workspace.Spec.Template.Components[1].Custom.EmbeddedResource.Raw = []byte(`{"config3": ""}`)
+	workspace.Spec.Template.Commands[0].Custom.EmbeddedResource.Raw = []byte(`{}`)
+
+	_, err := testRegistry(t).Decode(&workspace.Spec.Template.DevWorkspaceTemplateSpecContent)
+	assert.EqualError(t, err, "2 errors occurred:\n"+
+		"\t* failed to decode component 'myNewComponent' of custom class 'NewComponentType': json: unknown field \"config3\"\n"+
+		"\t* invalid command 'myCustomCommand' of custom class 'myCommandType': 'anyCustomConfigValue' is required\n\n")
+}
+
+func TestRegisterCustomClass(t *testing.T) {
+	registry := testRegistry(t)
+
+	assert.EqualError(t, registry.Register(CommandElementKind, "myCommandType", Handler{}), "custom command class 'myCommandType' is already registered")
+	assert.EqualError(t, registry.Register(CommandElementKind, "", Handler{}), "custom command class should not be empty")
+	assert.NoError(t, registry.Register(ComponentElementKind, "myCommandType", Handler{}))
+	assert.Equal(t, []string{"NewComponentType", "myCommandType"}, registry.Classes(ComponentElementKind))
+}
@@ -0,0 +1,97 @@
+// Package environment converts the environment variables of devfiles, whose values may come
+// from Secrets, ConfigMaps or the workspace metadata, to Kubernetes environment variables.
+package environment
+
+import (
+	"fmt"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// WorkspaceMetadata is the metadata of the workspace that runs the containers,
+// used by the environment variables that reference the workspace name or id.
+//
+// The workspace namespace is the namespace of the pods, so it is always known.
+type WorkspaceMetadata struct {
+	Name string
+	Id   string
+}
+
+// KubernetesEnv converts the environment variables of a container or a command.
+func KubernetesEnv(env []workspaces.EnvVar, metadata WorkspaceMetadata) ([]corev1.EnvVar, error) {
+	var errors *multierror.Error
+	var converted []corev1.EnvVar
+	for _, variable := range env {
+		kubernetesVariable, err := KubernetesEnvVar(variable, metadata)
+		if err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+		converted = append(converted, kubernetesVariable)
+	}
+	return converted, errors.ErrorOrNil()
+}
+
+// KubernetesEnvVar converts an environment variable:
+//
+// - Secret and ConfigMap keys become `secretKeyRef` and `configMapKeyRef` sources,
+//
+// - the workspace namespace becomes a `fieldRef` source on the namespace of the pod,
+//
+// - the workspace name and id become values, and an error is returned when they are not known.
+func KubernetesEnvVar(env workspaces.EnvVar, metadata WorkspaceMetadata) (corev1.EnvVar, error) {
+	converted := corev1.EnvVar{Name: env.Name, Value: env.Value}
+	source := env.ValueFrom
+	if source == nil {
+		return converted, nil
+	}
+	switch {
+	case source.SecretKeyRef != nil:
+		converted.ValueFrom = &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: source.SecretKeyRef.Name},
+				Key:                  source.SecretKeyRef.Key,
+				Optional:             optional(source.SecretKeyRef.Optional),
+			},
+		}
+	case source.ConfigMapKeyRef != nil:
+		converted.ValueFrom = &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: source.ConfigMapKeyRef.Name},
+				Key:                  source.ConfigMapKeyRef.Key,
+				Optional:             optional(source.ConfigMapKeyRef.Optional),
+			},
+		}
+	case source.WorkspaceRef != nil:
+		var value string
+		switch source.WorkspaceRef.Field {
+		case workspaces.NamespaceWorkspaceMetadataField:
+			converted.ValueFrom = &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+			}
+			return converted, nil
+		case workspaces.NameWorkspaceMetadataField:
+			value = metadata.Name
+		case workspaces.IdWorkspaceMetadataField:
+			value = metadata.Id
+		default:
+			return converted, fmt.Errorf("environment variable '%s' references the unknown '%s' field of the workspace", env.Name, source.WorkspaceRef.Field)
+		}
+		if value == "" {
+			return converted, fmt.Errorf("environment variable '%s' references the %s of the workspace, which is not known", env.Name, source.WorkspaceRef.Field)
+		}
+		converted.Value = value
+	default:
+		return converted, fmt.Errorf("environment variable '%s' has no source in 'valueFrom'", env.Name)
+	}
+	return converted, nil
+}
+
+func optional(isOptional bool) *bool {
+	if !isOptional {
+		return nil
+	}
+	return &isOptional
+}
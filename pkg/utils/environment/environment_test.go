@@ -0,0 +1,57 @@
+package environment
+
+import (
+	"strings"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestKubernetesEnv(t *testing.T) {
+	optional := true
+	env := []workspaces.EnvVar{
+		{Name: "NODE_ENV", Value: "production"},
+		{Name: "API_TOKEN", ValueFrom: &workspaces.EnvVarSource{
+			SecretKeyRef: &workspaces.KeySelector{Name: "api-credentials", Key: "token"},
+		}},
+		{Name: "LOG_LEVEL", ValueFrom: &workspaces.EnvVarSource{
+			ConfigMapKeyRef: &workspaces.KeySelector{Name: "app-config", Key: "logLevel", Optional: true},
+		}},
+		{Name: "NAMESPACE", ValueFrom: &workspaces.EnvVarSource{
+			WorkspaceRef: &workspaces.WorkspaceMetadataSelector{Field: workspaces.NamespaceWorkspaceMetadataField},
+		}},
+		{Name: "WORKSPACE", ValueFrom: &workspaces.EnvVarSource{
+			WorkspaceRef: &workspaces.WorkspaceMetadataSelector{Field: workspaces.NameWorkspaceMetadataField},
+		}},
+		{Name: "WORKSPACE_ID", ValueFrom: &workspaces.EnvVarSource{
+			WorkspaceRef: &workspaces.WorkspaceMetadataSelector{Field: workspaces.IdWorkspaceMetadataField},
+		}},
+	}
+
+	converted, err := KubernetesEnv(env, WorkspaceMetadata{Name: "web-app", Id: "workspace1234"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "NODE_ENV", Value: "production"},
+		{Name: "API_TOKEN", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api-credentials"}, Key: "token"},
+		}},
+		{Name: "LOG_LEVEL", ValueFrom: &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}, Key: "logLevel", Optional: &optional},
+		}},
+		{Name: "NAMESPACE", ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+		}},
+		{Name: "WORKSPACE", Value: "web-app"},
+		{Name: "WORKSPACE_ID", Value: "workspace1234"},
+	}, converted)
+
+	_, err = KubernetesEnv(env, WorkspaceMetadata{Name: "web-app"})
+	if assert.Error(t, err) {
+		assert.Equal(t, `1 error occurred:
+	* environment variable 'WORKSPACE_ID' references the id of the workspace, which is not known`, strings.TrimSpace(err.Error()))
+	}
+}
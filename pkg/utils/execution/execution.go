@@ -0,0 +1,92 @@
+// Package execution runs the `exec` commands of workspaces in their container components,
+// either in the containers of a running workspace pod or as local processes.
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+)
+
+// Shell is the shell that runs the command lines of the commands
+const Shell = "/bin/sh"
+
+// Streams are the standard streams of a command.
+// Nil streams are not attached to the command.
+type Streams struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Executor runs `exec` commands in their container component.
+type Executor interface {
+	// Execute runs the command line of an `exec` command through a shell, in the working directory
+	// and with the environment variables of the command, and streams its output while it runs.
+	//
+	// Execute returns once the command exits, with an `*ExitError` if the command exits with a non-zero status,
+	// or once the context is done, with the error of the context.
+	Execute(ctx context.Context, container workspaces.Component, command workspaces.ExecCommand, streams Streams) error
+}
+
+// ExitError is returned when a command exits with a non-zero status.
+type ExitError struct {
+	ExitCode int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("command exited with the %d status", e.ExitCode)
+}
+
+// ExitCode returns the exit code of a command from the error returned by an executor:
+// `0` when there is no error, the exit code of an `*ExitError`, and `-1` when the command could not run
+// or did not exit.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitError *ExitError
+	if errors.As(err, &exitError) {
+		return exitError.ExitCode
+	}
+	return -1
+}
+
+// checkEnv returns an error if an environment variable of the command reads its value from a source,
+// since sources are only resolved by Kubernetes when creating containers.
+func checkEnv(command workspaces.ExecCommand) error {
+	for _, env := range command.Env {
+		if env.ValueFrom != nil {
+			return fmt.Errorf("environment variable '%s' reads its value from 'valueFrom', which is not supported when running commands", env.Name)
+		}
+	}
+	return nil
+}
+
+// shellScript returns a shell script that exports the environment variables of the command,
+// changes to its working directory and runs its command line.
+//
+// The working directory is double-quoted, so that the `$PROJECTS_ROOT` and `$PROJECT_SOURCE` variables are expanded.
+func shellScript(command workspaces.ExecCommand) string {
+	var lines []string
+	for _, env := range command.Env {
+		lines = append(lines, "export "+env.Name+"="+singleQuote(env.Value))
+	}
+	if command.WorkingDir != "" {
+		lines = append(lines, "cd "+doubleQuote(command.WorkingDir)+" || exit $?")
+	}
+	lines = append(lines, command.CommandLine)
+	return strings.Join(lines, "\n")
+}
+
+func singleQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func doubleQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`").Replace(value) + `"`
+}
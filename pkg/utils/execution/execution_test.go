@@ -0,0 +1,150 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+var tools = workspaces.Component{
+	Name: "tools",
+	ComponentUnion: workspaces.ComponentUnion{
+		Container: &workspaces.ContainerComponent{
+			Container: workspaces.Container{
+				Image: "quay.io/example/tools:latest",
+				Env: []workspaces.EnvVar{
+					{Name: "GREETING", Value: "hello"},
+					{Name: "TOKEN", ValueFrom: &workspaces.EnvVarSource{
+						SecretKeyRef: &workspaces.KeySelector{Name: "credentials", Key: "token"},
+					}},
+				},
+			},
+		},
+	},
+}
+
+func tempProjectsRoot(t *testing.T) string {
+	root, err := ioutil.TempDir("", "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "app", "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestLocalExecutor(t *testing.T) {
+	root := tempProjectsRoot(t)
+	defer os.RemoveAll(root)
+	executor := &LocalExecutor{ProjectsRoot: root, ProjectSource: "app"}
+
+	var stdout, stderr bytes.Buffer
+	err := executor.Execute(context.Background(), tools, workspaces.ExecCommand{
+		CommandLine: `echo "$GREETING $NAME from $(pwd)"; echo "token: $TOKEN" >&2`,
+		WorkingDir:  "${PROJECT_SOURCE}/src",
+		Env:         []workspaces.EnvVar{{Name: "NAME", Value: "world"}},
+	}, Streams{Stdout: &stdout, Stderr: &stderr})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world from "+filepath.Join(root, "app", "src")+"\n", stdout.String())
+	assert.Equal(t, "token: \n", stderr.String())
+}
+
+func TestLocalExecutorExitCode(t *testing.T) {
+	root := tempProjectsRoot(t)
+	defer os.RemoveAll(root)
+	executor := &LocalExecutor{ProjectsRoot: root}
+
+	err := executor.Execute(context.Background(), tools, workspaces.ExecCommand{CommandLine: "exit 3"}, Streams{})
+	assert.EqualError(t, err, "command exited with the 3 status")
+	assert.Equal(t, 3, ExitCode(err))
+}
+
+func TestLocalExecutorCancellation(t *testing.T) {
+	root := tempProjectsRoot(t)
+	defer os.RemoveAll(root)
+	executor := &LocalExecutor{ProjectsRoot: root}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := executor.Execute(ctx, tools, workspaces.ExecCommand{CommandLine: "sleep 10"}, Streams{})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, -1, ExitCode(err))
+	assert.True(t, time.Since(start) < 5*time.Second, "the command should be killed")
+}
+
+func TestExecutorErrors(t *testing.T) {
+	volume := workspaces.Component{
+		Name:           "sources",
+		ComponentUnion: workspaces.ComponentUnion{Volume: &workspaces.VolumeComponent{}},
+	}
+	executor := &LocalExecutor{ProjectsRoot: os.TempDir()}
+	err := executor.Execute(context.Background(), volume, workspaces.ExecCommand{CommandLine: "true"}, Streams{})
+	assert.EqualError(t, err, "component 'sources' is not a container component")
+
+	err = executor.Execute(context.Background(), tools, workspaces.ExecCommand{
+		CommandLine: "true",
+		Env:         tools.Container.Env,
+	}, Streams{})
+	assert.EqualError(t, err, "environment variable 'TOKEN' reads its value from 'valueFrom', which is not supported when running commands")
+}
+
+func TestShellScript(t *testing.T) {
+	root := tempProjectsRoot(t)
+	defer os.RemoveAll(root)
+	quotedDir := filepath.Join(root, `it's "quoted"`)
+	if err := os.Mkdir(quotedDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	script := shellScript(workspaces.ExecCommand{
+		CommandLine: `echo "$MESSAGE"; basename "$(pwd)"`,
+		WorkingDir:  "$PROJECTS_ROOT/it's \"quoted\"",
+		Env:         []workspaces.EnvVar{{Name: "MESSAGE", Value: `it's $HOME and "quotes"`}},
+	})
+	cmd := exec.Command(Shell, "-c", script)
+	cmd.Env = append(os.Environ(), "PROJECTS_ROOT="+root)
+	output, err := cmd.Output()
+	assert.NoError(t, err)
+	assert.Equal(t, "it's $HOME and \"quotes\"\nit's \"quoted\"\n", string(output))
+
+	script = shellScript(workspaces.ExecCommand{CommandLine: "echo unreachable", WorkingDir: "/does/not/exist"})
+	output, err = exec.Command(Shell, "-c", script).Output()
+	assert.Error(t, err)
+	assert.False(t, strings.Contains(string(output), "unreachable"))
+}
+
+func TestFakeExecutor(t *testing.T) {
+	failure := errors.New("connection refused")
+	executor := &FakeExecutor{Results: map[string]FakeResult{
+		"npm test":    {Stdout: "1 failing\n", Stderr: "npm ERR!\n", ExitCode: 1},
+		"npm run dev": {Err: failure},
+	}}
+
+	var stdout, stderr bytes.Buffer
+	err := executor.Execute(context.Background(), tools, workspaces.ExecCommand{CommandLine: "npm install"}, Streams{Stdout: &stdout})
+	assert.NoError(t, err)
+	err = executor.Execute(context.Background(), tools, workspaces.ExecCommand{CommandLine: "npm test"}, Streams{Stdout: &stdout, Stderr: &stderr})
+	assert.Equal(t, 1, ExitCode(err))
+	assert.Equal(t, "1 failing\n", stdout.String())
+	assert.Equal(t, "npm ERR!\n", stderr.String())
+	err = executor.Execute(context.Background(), tools, workspaces.ExecCommand{CommandLine: "npm run dev"}, Streams{})
+	assert.Equal(t, failure, err)
+
+	assert.Equal(t, []Execution{
+		{Component: "tools", Command: workspaces.ExecCommand{CommandLine: "npm install"}},
+		{Component: "tools", Command: workspaces.ExecCommand{CommandLine: "npm test"}},
+		{Component: "tools", Command: workspaces.ExecCommand{CommandLine: "npm run dev"}},
+	}, executor.Executions())
+}
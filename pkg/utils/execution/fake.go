@@ -0,0 +1,74 @@
+package execution
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+)
+
+// Execution is a command run by a `FakeExecutor`
+type Execution struct {
+	Component string
+	Command   workspaces.ExecCommand
+}
+
+// FakeResult is the result of the commands run by a `FakeExecutor` for a given command line
+type FakeResult struct {
+	// Written to the standard output of the command
+	Stdout string
+	// Written to the standard error of the command
+	Stderr string
+	// Exit code of the command
+	ExitCode int
+	// Returned instead of running the command, when not nil
+	Err error
+}
+
+// FakeExecutor is an `Executor` for tests, that records the commands it runs instead of running them.
+// It can be used concurrently.
+type FakeExecutor struct {
+	// Results of the commands, by command line.
+	// Commands without result succeed without output.
+	Results map[string]FakeResult
+
+	lock       sync.Mutex
+	executions []Execution
+}
+
+// Execute records the command, and writes the output and returns the exit code of its result.
+func (f *FakeExecutor) Execute(ctx context.Context, container workspaces.Component, command workspaces.ExecCommand, streams Streams) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	f.executions = append(f.executions, Execution{Component: container.Name, Command: command})
+	result := f.Results[command.CommandLine]
+	f.lock.Unlock()
+
+	if result.Err != nil {
+		return result.Err
+	}
+	if streams.Stdout != nil {
+		if _, err := io.WriteString(streams.Stdout, result.Stdout); err != nil {
+			return err
+		}
+	}
+	if streams.Stderr != nil {
+		if _, err := io.WriteString(streams.Stderr, result.Stderr); err != nil {
+			return err
+		}
+	}
+	if result.ExitCode != 0 {
+		return &ExitError{ExitCode: result.ExitCode}
+	}
+	return nil
+}
+
+// Executions returns the commands run by the executor, in order.
+func (f *FakeExecutor) Executions() []Execution {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]Execution{}, f.executions...)
+}
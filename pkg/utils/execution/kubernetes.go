@@ -0,0 +1,242 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// KubernetesExecutor is an `Executor` that runs the commands in the containers of a running workspace pod,
+// through the `exec` subresource of the pod, as `kubectl exec` does.
+//
+// The containers of the pod are expected to be named after their container components, and to provide `/bin/sh`.
+// Commands that run without a standard input can only be cancelled in containers where `/proc` is mounted and readable,
+// since the processes of the command are found there: in other containers, Execute returns an error
+// instead of starting a command that it could not stop.
+type KubernetesExecutor struct {
+	// Configuration of the connection to the cluster
+	Config *rest.Config
+
+	// Client of the cluster
+	Client kubernetes.Interface
+
+	// Namespace of the workspace pod
+	Namespace string
+
+	// Name of the workspace pod
+	Pod string
+
+	lock sync.Mutex
+	// Whether the processes of the containers can be listed in `/proc`, by container name
+	listsProcesses map[string]bool
+}
+
+// cancellationGracePeriod is how long `Execute` waits for a cancelled command to exit,
+// before closing the connection to the pod
+var cancellationGracePeriod = 5 * time.Second
+
+// watchStdin runs the script given as first argument in the background, and kills it,
+// with the processes it started, once its standard input is closed.
+// The processes are found in `/proc` before being killed, since they are not in a process group of their own
+// when the shell has no job control. The processes started by the script are killed before the script itself,
+// since the watcher is killed as soon as the script exits.
+const watchStdin = `exec 3<&0
+` + Shell + ` -c "$1" </dev/null 3<&- &
+command=$!
+(
+  while read -r _; do :; done <&3
+  pids=""
+  parents=$command
+  while [ -n "$parents" ]; do
+    children=""
+    for stat in /proc/[0-9]*/stat; do
+      read -r line <"$stat" || continue
+      set -- ${line##*) }
+      case " $parents " in *" $2 "*) children="$children ${line%% *}" ;; esac
+    done
+    pids="$pids$children"
+    parents=${children# }
+  done
+  kill -TERM $pids $command
+) </dev/null >/dev/null 2>&1 &
+watcher=$!
+exec 3<&-
+wait $command
+status=$?
+kill $watcher 2>/dev/null
+exit $status`
+
+// listProcesses exits successfully when the processes of the container can be read in `/proc`, as `watchStdin` does
+const listProcesses = `for stat in /proc/[0-9]*/stat; do [ -r "$stat" ] && exit 0; done; exit 1`
+
+// Execute runs the command line with `/bin/sh -c` in the container of the component.
+// The environment variables of the container are set by the container itself.
+//
+// When no standard input is given, the command line runs behind a shell script that reads the standard input
+// of the remote process, and the standard input is closed when the context is done, so that the script
+// kills the command. This requires a readable `/proc` in the container, which is checked once per container.
+//
+// When a standard input is given, the command is expected to exit once its input is closed,
+// and cancellation otherwise relies on the grace period: the connection to the pod is closed if the command
+// doesn't exit within the grace period, which may leave the remote process running.
+// In both cases, Execute returns the error of the context once the stream is over.
+func (k *KubernetesExecutor) Execute(ctx context.Context, container workspaces.Component, command workspaces.ExecCommand, streams Streams) error {
+	if container.Container == nil {
+		return fmt.Errorf("component '%s' is not a container component", container.Name)
+	}
+	if err := checkEnv(command); err != nil {
+		return err
+	}
+
+	stdin := streams.Stdin
+	closeStdin := func() {}
+	commandLine := []string{Shell, "-c", shellScript(command)}
+	if stdin == nil {
+		if err := k.checkProcesses(container.Name); err != nil {
+			return err
+		}
+		reader, writer := io.Pipe()
+		// Also stops the copy of the standard input, once the stream is over
+		defer writer.Close()
+		stdin = reader
+		closeStdin = func() { writer.Close() }
+		commandLine = []string{Shell, "-c", watchStdin, Shell, shellScript(command)}
+	}
+
+	executor, connection, err := k.newExecutor(&corev1.PodExecOptions{
+		Container: container.Name,
+		Command:   commandLine,
+		Stdin:     true,
+		Stdout:    streams.Stdout != nil,
+		Stderr:    streams.Stderr != nil,
+	})
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- executor.Stream(remotecommand.StreamOptions{
+			Stdin:  stdin,
+			Stdout: streams.Stdout,
+			Stderr: streams.Stderr,
+		})
+	}()
+	select {
+	case <-ctx.Done():
+		closeStdin()
+		select {
+		case <-done:
+		case <-time.After(cancellationGracePeriod):
+			connection.Close()
+			<-done
+		}
+		return ctx.Err()
+	case err := <-done:
+		if exitError, isExitError := err.(utilexec.ExitError); isExitError && exitError.Exited() {
+			return &ExitError{ExitCode: exitError.ExitStatus()}
+		}
+		return err
+	}
+}
+
+// newExecutor prepares the execution of a command in the workspace pod
+func (k *KubernetesExecutor) newExecutor(options *corev1.PodExecOptions) (remotecommand.Executor, *connectionCloser, error) {
+	request := k.Client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(k.Namespace).
+		Name(k.Pod).
+		SubResource("exec").
+		VersionedParams(options, scheme.ParameterCodec)
+	transport, upgrader, err := spdy.RoundTripperFor(k.Config)
+	if err != nil {
+		return nil, nil, err
+	}
+	connection := &connectionCloser{Upgrader: upgrader}
+	executor, err := remotecommand.NewSPDYExecutorForTransports(transport, connection, "POST", request.URL())
+	if err != nil {
+		return nil, nil, err
+	}
+	return executor, connection, nil
+}
+
+// checkProcesses checks that the processes of the container can be listed in `/proc`,
+// so that the commands run without a standard input can be killed when they are cancelled.
+// The result is kept for the next commands of the container, unless the check itself fails.
+func (k *KubernetesExecutor) checkProcesses(container string) error {
+	k.lock.Lock()
+	listsProcesses, checked := k.listsProcesses[container]
+	k.lock.Unlock()
+	if !checked {
+		executor, _, err := k.newExecutor(&corev1.PodExecOptions{
+			Container: container,
+			Command:   []string{Shell, "-c", listProcesses},
+		})
+		if err != nil {
+			return err
+		}
+		err = executor.Stream(remotecommand.StreamOptions{})
+		if exitError, isExitError := err.(utilexec.ExitError); err != nil && !(isExitError && exitError.Exited()) {
+			return err
+		}
+		listsProcesses = err == nil
+		k.lock.Lock()
+		if k.listsProcesses == nil {
+			k.listsProcesses = map[string]bool{}
+		}
+		k.listsProcesses[container] = listsProcesses
+		k.lock.Unlock()
+	}
+	if !listsProcesses {
+		return fmt.Errorf("commands without a standard input cannot be cancelled in container '%s', since its processes cannot be read in /proc", container)
+	}
+	return nil
+}
+
+// connectionCloser is a `spdy.Upgrader` that keeps the connection it upgrades, so that it can be closed
+// from outside of the stream.
+type connectionCloser struct {
+	spdy.Upgrader
+
+	lock       sync.Mutex
+	connection httpstream.Connection
+	closed     bool
+}
+
+func (c *connectionCloser) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	connection, err := c.Upgrader.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		connection.Close()
+		return nil, fmt.Errorf("the connection was closed")
+	}
+	c.connection = connection
+	return connection, nil
+}
+
+// Close closes the connection, or the connection upgraded later on
+func (c *connectionCloser) Close() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.closed = true
+	if c.connection != nil {
+		c.connection.Close()
+	}
+}
@@ -0,0 +1,189 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+	"k8s.io/apimachinery/pkg/util/remotecommand"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// newExecServer serves the `exec` subresource of pods with the version 4 of the streaming protocol,
+// and runs the commands as local processes. As the kubelet does, the standard input of the process
+// is closed when the standard input stream or the connection is closed.
+//
+// When `procReadable` is false, the server behaves as a container without a readable `/proc`.
+func newExecServer(t *testing.T, procReadable bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := httpstream.Handshake(r, w, []string{remotecommand.StreamProtocolV4Name}); err != nil {
+			return
+		}
+		query := r.URL.Query()
+		expected := 1
+		for _, stream := range []string{"stdin", "stdout", "stderr"} {
+			if query.Get(stream) == "true" {
+				expected++
+			}
+		}
+		created := make(chan httpstream.Stream, expected)
+		connection := spdy.NewResponseUpgrader().UpgradeResponse(w, r, func(stream httpstream.Stream, replySent <-chan struct{}) error {
+			created <- stream
+			return nil
+		})
+		if connection == nil {
+			return
+		}
+		defer connection.Close()
+		streams := map[string]httpstream.Stream{}
+		for len(streams) < expected {
+			stream := <-created
+			streams[stream.Headers().Get(corev1.StreamType)] = stream
+		}
+
+		command := query["command"]
+		if !procReadable && command[len(command)-1] == listProcesses {
+			command = []string{Shell, "-c", "exit 1"}
+		}
+		cmd := exec.Command(command[0], command[1:]...)
+		processStdin, err := cmd.StdinPipe()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if stdout, exists := streams[corev1.StreamTypeStdout]; exists {
+			cmd.Stdout = stdout
+		}
+		if stderr, exists := streams[corev1.StreamTypeStderr]; exists {
+			cmd.Stderr = stderr
+		}
+		if err := cmd.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		if stdin, exists := streams[corev1.StreamTypeStdin]; exists {
+			go func() {
+				io.Copy(processStdin, stdin)
+				processStdin.Close()
+			}()
+		}
+
+		status := metav1.Status{Status: metav1.StatusSuccess}
+		if exitError, isExitError := cmd.Wait().(*exec.ExitError); isExitError {
+			status = metav1.Status{
+				Status: metav1.StatusFailure,
+				Reason: remotecommand.NonZeroExitCodeReason,
+				Details: &metav1.StatusDetails{
+					Causes: []metav1.StatusCause{{
+						Type:    remotecommand.ExitCodeCauseType,
+						Message: strconv.Itoa(exitError.ExitCode()),
+					}},
+				},
+			}
+		}
+		json.NewEncoder(streams[corev1.StreamTypeError]).Encode(status)
+		for _, stream := range streams {
+			stream.Close()
+		}
+	}))
+}
+
+func newKubernetesExecutor(t *testing.T, server *httptest.Server) *KubernetesExecutor {
+	config := &rest.Config{Host: server.URL}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &KubernetesExecutor{Config: config, Client: client, Namespace: "workspaces", Pod: "workspace"}
+}
+
+func TestKubernetesExecutor(t *testing.T) {
+	server := newExecServer(t, true)
+	defer server.Close()
+	executor := newKubernetesExecutor(t, server)
+
+	var stdout, stderr bytes.Buffer
+	err := executor.Execute(context.Background(), tools, workspaces.ExecCommand{
+		CommandLine: `echo "hello $NAME"; echo "from stderr" >&2`,
+		Env:         []workspaces.EnvVar{{Name: "NAME", Value: "world"}},
+	}, Streams{Stdout: &stdout, Stderr: &stderr})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world\n", stdout.String())
+	assert.Equal(t, "from stderr\n", stderr.String())
+
+	stdout.Reset()
+	err = executor.Execute(context.Background(), tools, workspaces.ExecCommand{CommandLine: "cat"},
+		Streams{Stdin: strings.NewReader("from stdin"), Stdout: &stdout})
+	assert.NoError(t, err)
+	assert.Equal(t, "from stdin", stdout.String())
+
+	err = executor.Execute(context.Background(), tools, workspaces.ExecCommand{CommandLine: "exit 3"}, Streams{})
+	assert.EqualError(t, err, "command exited with the 3 status")
+}
+
+func TestKubernetesExecutorCancellation(t *testing.T) {
+	if _, err := os.Stat("/proc/self/stat"); err != nil {
+		t.Skip("/proc is not available")
+	}
+	server := newExecServer(t, true)
+	defer server.Close()
+	executor := newKubernetesExecutor(t, server)
+	tmpDir, err := ioutil.TempDir("", "execution")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	pidFile := filepath.Join(tmpDir, "pid")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = executor.Execute(ctx, tools, workspaces.ExecCommand{
+		CommandLine: `sleep 30 & echo $! > "$PID_FILE"; wait`,
+		Env:         []workspaces.EnvVar{{Name: "PID_FILE", Value: pidFile}},
+	}, Streams{})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < cancellationGracePeriod, "the command should be killed before the grace period")
+
+	pid, err := ioutil.ReadFile(pidFile)
+	if !assert.NoError(t, err) {
+		return
+	}
+	stat, err := ioutil.ReadFile("/proc/" + strings.TrimSpace(string(pid)) + "/stat")
+	if err == nil {
+		assert.Contains(t, string(stat), ") Z ", "the processes started by the command should be killed")
+	}
+}
+
+func TestKubernetesExecutorWithoutProc(t *testing.T) {
+	server := newExecServer(t, false)
+	defer server.Close()
+	executor := newKubernetesExecutor(t, server)
+
+	err := executor.Execute(context.Background(), tools, workspaces.ExecCommand{CommandLine: "true"}, Streams{})
+	assert.EqualError(t, err, "commands without a standard input cannot be cancelled in container 'tools', since its processes cannot be read in /proc")
+
+	var stdout bytes.Buffer
+	err = executor.Execute(context.Background(), tools, workspaces.ExecCommand{CommandLine: "cat"},
+		Streams{Stdin: strings.NewReader("from stdin"), Stdout: &stdout})
+	assert.NoError(t, err)
+	assert.Equal(t, "from stdin", stdout.String())
+}
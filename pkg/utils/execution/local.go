@@ -0,0 +1,78 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+)
+
+// LocalExecutor is an `Executor` that runs the commands as local processes,
+// for tools that run the workspace on the local machine with the sources in a local directory.
+//
+// The container images are not used: the commands run with the tools of the local machine,
+// and with the environment variables of the local process, of the container component and of the command.
+// The variables of the container component that read their values from a source are not set.
+type LocalExecutor struct {
+	// Local directory that contains the projects, exposed to the commands as `$PROJECTS_ROOT`
+	ProjectsRoot string
+
+	// Path of the project source, relative to `ProjectsRoot` and exposed to the commands as `$PROJECT_SOURCE`
+	ProjectSource string
+}
+
+// Execute runs the command line with `/bin/sh -c`, in the working directory of the command,
+// or in the projects root directory when the command has no working directory.
+// The process is killed when the context is done.
+func (l *LocalExecutor) Execute(ctx context.Context, container workspaces.Component, command workspaces.ExecCommand, streams Streams) error {
+	if container.Container == nil {
+		return fmt.Errorf("component '%s' is not a container component", container.Name)
+	}
+	if err := checkEnv(command); err != nil {
+		return err
+	}
+
+	variables := map[string]string{
+		"PROJECTS_ROOT":  l.ProjectsRoot,
+		"PROJECT_SOURCE": filepath.Join(l.ProjectsRoot, filepath.FromSlash(l.ProjectSource)),
+	}
+	env := os.Environ()
+	for name, value := range variables {
+		env = append(env, name+"="+value)
+	}
+	for _, vars := range [][]workspaces.EnvVar{container.Container.Env, command.Env} {
+		for _, v := range vars {
+			if v.ValueFrom != nil {
+				continue
+			}
+			env = append(env, v.Name+"="+v.Value)
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, Shell, "-c", command.CommandLine)
+	cmd.Env = env
+	cmd.Dir = l.ProjectsRoot
+	if command.WorkingDir != "" {
+		cmd.Dir = os.Expand(command.WorkingDir, func(name string) string {
+			if value, found := variables[name]; found {
+				return value
+			}
+			return os.Getenv(name)
+		})
+	}
+	cmd.Stdin = streams.Stdin
+	cmd.Stdout = streams.Stdout
+	cmd.Stderr = streams.Stderr
+
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if exitError, isExitError := err.(*exec.ExitError); isExitError {
+		return &ExitError{ExitCode: exitError.ExitCode()}
+	}
+	return err
+}
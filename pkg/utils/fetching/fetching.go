@@ -0,0 +1,165 @@
+package fetching
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/devfile/api/pkg/utils/credentials"
+)
+
+// Fetcher retrieves the raw content available at a given location,
+// such as the uri of a Kubernetes manifest, a parent devfile or a plugin.
+type Fetcher interface {
+	Fetch(uri string) ([]byte, error)
+}
+
+// FetcherFunc allows using an ordinary function as a `Fetcher`.
+type FetcherFunc func(uri string) ([]byte, error)
+
+// Fetch calls f(uri).
+func (f FetcherFunc) Fetch(uri string) ([]byte, error) {
+	return f(uri)
+}
+
+// CredentialsFetcher is a `Fetcher` that can authenticate to the location it fetches from.
+type CredentialsFetcher interface {
+	Fetcher
+	// FetchWithCredentials retrieves the content available at the given uri,
+	// authenticating with the given credentials when they are not nil.
+	FetchWithCredentials(uri string, credentials *credentials.Credentials) ([]byte, error)
+}
+
+// FetchWithCredentials retrieves the content available at the given uri with the given fetcher,
+// authenticating with the given credentials when they are not nil.
+// Returns an error if credentials are provided and the fetcher is not a `CredentialsFetcher`.
+func FetchWithCredentials(fetcher Fetcher, uri string, creds *credentials.Credentials) ([]byte, error) {
+	if creds == nil {
+		return fetcher.Fetch(uri)
+	}
+	credentialsFetcher, supportsCredentials := fetcher.(CredentialsFetcher)
+	if !supportsCredentials {
+		return nil, fmt.Errorf("cannot fetch '%s' with credentials: the fetcher doesn't support credentials", credentials.RedactURL(uri))
+	}
+	return credentialsFetcher.FetchWithCredentials(uri, creds)
+}
+
+const (
+	// DefaultTimeout is the timeout of the requests of a `URIFetcher` without client
+	DefaultTimeout = 30 * time.Second
+
+	// MaxContentSize is the maximum size, in bytes, of the content fetched from `http` and `https` uris
+	MaxContentSize = 10 * 1024 * 1024
+)
+
+// defaultClient is the client of a `URIFetcher` without client
+var defaultClient = &http.Client{Timeout: DefaultTimeout}
+
+// URIFetcher is a `Fetcher` that supports `http`, `https` and `file` uris,
+// as well as local file paths.
+type URIFetcher struct {
+	// Client used to fetch `http` and `https` uris.
+	// When nil, a client with the `DefaultTimeout` is used.
+	Client *http.Client
+}
+
+// DefaultFetcher is the `Fetcher` used when none is provided explicitly.
+var DefaultFetcher Fetcher = &URIFetcher{}
+
+// Fetch retrieves the content available at the given uri.
+func (f *URIFetcher) Fetch(uri string) ([]byte, error) {
+	return f.FetchWithCredentials(uri, nil)
+}
+
+// FetchWithCredentials retrieves the content available at the given uri.
+// Credentials are only used for `http` and `https` uris:
+// a token is sent as a bearer token, and a username and password with basic authentication.
+// Secrets are redacted from the returned errors.
+func (f *URIFetcher) FetchWithCredentials(uri string, creds *credentials.Credentials) ([]byte, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, creds.RedactError(err)
+	}
+	switch parsed.Scheme {
+	case "http", "https":
+		client := f.Client
+		if client == nil {
+			client = defaultClient
+		}
+		req, err := http.NewRequest(http.MethodGet, uri, nil)
+		if err != nil {
+			return nil, creds.RedactError(err)
+		}
+		if creds != nil {
+			switch {
+			case creds.Token != "":
+				req.Header.Set("Authorization", "Bearer "+creds.Token)
+			case creds.Password != "":
+				req.SetBasicAuth(creds.Username, creds.Password)
+			}
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, creds.RedactError(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch '%s': %s", credentials.RedactURL(uri), resp.Status)
+		}
+		content, err := ioutil.ReadAll(io.LimitReader(resp.Body, MaxContentSize+1))
+		if err != nil {
+			return nil, creds.RedactError(err)
+		}
+		if len(content) > MaxContentSize {
+			return nil, fmt.Errorf("failed to fetch '%s': the content is larger than %d bytes", credentials.RedactURL(uri), MaxContentSize)
+		}
+		return content, nil
+	case "file":
+		return ioutil.ReadFile(filepath.FromSlash(parsed.Path))
+	case "":
+		return ioutil.ReadFile(uri)
+	default:
+		return nil, fmt.Errorf("unsupported scheme '%s' in uri '%s'", parsed.Scheme, uri)
+	}
+}
+
+// ResolveURI resolves a possibly-relative `uri` against the `base` uri
+// of the document that references it.
+//
+// Absolute uris are returned unchanged, as well as any uri when `base` is empty.
+// When `base` is a local file path, `uri` is resolved relatively to the directory
+// that contains it.
+//
+// When `base` is an `http` or `https` uri, absolute paths are resolved against the host of `base`,
+// and `file` uris are rejected, so that a remote document cannot read local files.
+func ResolveURI(base, uri string) (string, error) {
+	ref, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	if isRemote(baseURL) {
+		if ref.Scheme == "file" {
+			return "", fmt.Errorf("uri '%s' references a local file, which is not allowed in the remote document '%s'", uri, credentials.RedactURL(base))
+		}
+		return baseURL.ResolveReference(ref).String(), nil
+	}
+	if base == "" || ref.IsAbs() || filepath.IsAbs(uri) {
+		return uri, nil
+	}
+	if baseURL.Scheme == "" {
+		return filepath.Join(filepath.Dir(base), filepath.FromSlash(uri)), nil
+	}
+	return baseURL.ResolveReference(ref).String(), nil
+}
+
+func isRemote(u *url.URL) bool {
+	return u.Scheme == "http" || u.Scheme == "https"
+}
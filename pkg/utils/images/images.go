@@ -0,0 +1,65 @@
+// Package images resolves the container images built by the `image` components of devfiles,
+// and run by the container components that reference them.
+package images
+
+import (
+	"path"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+)
+
+// DefaultDockerfile is the path of the Dockerfile, relative to the build context,
+// of the image components without `dockerfile`.
+const DefaultDockerfile = "Dockerfile"
+
+// DefaultBuildContext is the build context, relative to the project source,
+// of the image components without `buildContext`.
+const DefaultBuildContext = "."
+
+// Build is the build of an image component, with the default values applied
+type Build struct {
+	// Name of the image component
+	Component string
+
+	workspaces.Image
+}
+
+// DockerfilePath returns the path of the Dockerfile, relative to the project source.
+func (b Build) DockerfilePath() string {
+	return path.Join(b.BuildContext, b.Dockerfile)
+}
+
+// Builds returns the builds of the image components, in the order of the components.
+func Builds(components []workspaces.Component) []Build {
+	var builds []Build
+	for _, component := range components {
+		if component.Image != nil {
+			builds = append(builds, newBuild(component.Name, component.Image.Image))
+		}
+	}
+	return builds
+}
+
+// ContainerImage returns the image run by a container:
+// when the container image is the name of an image component, it is the image built by this component,
+// which is also returned. Otherwise, it is the container image itself.
+func ContainerImage(components []workspaces.Component, container workspaces.Container) (string, *Build) {
+	for _, component := range components {
+		if component.Image != nil && component.Name == container.Image {
+			build := newBuild(component.Name, component.Image.Image)
+			return build.ImageName, &build
+		}
+	}
+	return container.Image, nil
+}
+
+func newBuild(name string, image workspaces.Image) Build {
+	build := Build{Component: name, Image: image}
+	if build.Dockerfile == "" {
+		build.Dockerfile = DefaultDockerfile
+	}
+	if build.BuildContext == "" {
+		build.BuildContext = DefaultBuildContext
+	}
+	return build
+}
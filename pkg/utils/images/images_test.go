@@ -0,0 +1,59 @@
+package images
+
+import (
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+var components = []workspaces.Component{
+	{
+		Name: "app",
+		ComponentUnion: workspaces.ComponentUnion{
+			Image: &workspaces.ImageComponent{
+				Image: workspaces.Image{
+					ImageName:    "quay.io/example/app:latest",
+					BuildContext: "docker",
+				},
+			},
+		},
+	},
+	{
+		Name: "runtime",
+		ComponentUnion: workspaces.ComponentUnion{
+			Container: &workspaces.ContainerComponent{
+				Container: workspaces.Container{Image: "app"},
+			},
+		},
+	},
+	{
+		Name: "database",
+		ComponentUnion: workspaces.ComponentUnion{
+			Container: &workspaces.ContainerComponent{
+				Container: workspaces.Container{Image: "postgres:13"},
+			},
+		},
+	},
+}
+
+func TestBuilds(t *testing.T) {
+	builds := Builds(components)
+	if assert.Len(t, builds, 1) {
+		assert.Equal(t, "app", builds[0].Component)
+		assert.Equal(t, "Dockerfile", builds[0].Dockerfile)
+		assert.Equal(t, "docker/Dockerfile", builds[0].DockerfilePath())
+	}
+}
+
+func TestContainerImage(t *testing.T) {
+	image, build := ContainerImage(components, components[1].Container.Container)
+	assert.Equal(t, "quay.io/example/app:latest", image)
+	if assert.NotNil(t, build) {
+		assert.Equal(t, "app", build.Component)
+	}
+
+	image, build = ContainerImage(components, components[2].Container.Container)
+	assert.Equal(t, "postgres:13", image)
+	assert.Nil(t, build)
+}
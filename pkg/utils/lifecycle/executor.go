@@ -0,0 +1,56 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/execution"
+)
+
+// Applier applies the components targeted by `apply` commands, as `Executor.Apply` does.
+type Applier interface {
+	Apply(ctx context.Context, event Event, component workspaces.Component) error
+}
+
+// ApplierFunc allows using an ordinary function as an `Applier`.
+type ApplierFunc func(ctx context.Context, event Event, component workspaces.Component) error
+
+// Apply calls f(ctx, event, component).
+func (f ApplierFunc) Apply(ctx context.Context, event Event, component workspaces.Component) error {
+	return f(ctx, event, component)
+}
+
+// CommandExecutor is an `Executor` that runs the `exec` commands with an `execution.Executor`,
+// such as the `execution.KubernetesExecutor` or the `execution.LocalExecutor`,
+// and applies the components of `apply` commands with an `Applier`.
+type CommandExecutor struct {
+	// Runs the `exec` commands in their container
+	Executor execution.Executor
+
+	// Applies the components of `apply` commands.
+	// When nil, `apply` commands fail.
+	Applier Applier
+
+	// Streams returns the streams of an `exec` command, for example to log its output.
+	// It is called once per run, and may be called concurrently for the commands of parallel composite commands.
+	// When nil, the commands run without streams.
+	Streams func(container workspaces.Component, command workspaces.ExecCommand) execution.Streams
+}
+
+// Apply applies the component with the `Applier`.
+func (c *CommandExecutor) Apply(ctx context.Context, event Event, component workspaces.Component) error {
+	if c.Applier == nil {
+		return fmt.Errorf("applying components is not supported")
+	}
+	return c.Applier.Apply(ctx, event, component)
+}
+
+// Exec runs the command with the `execution.Executor`.
+func (c *CommandExecutor) Exec(ctx context.Context, container workspaces.Component, command workspaces.ExecCommand) error {
+	var streams execution.Streams
+	if c.Streams != nil {
+		streams = c.Streams(container, command)
+	}
+	return c.Executor.Execute(ctx, container, command, streams)
+}
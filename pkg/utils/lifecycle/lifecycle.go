@@ -0,0 +1,218 @@
+// Package lifecycle runs the commands bound to the lifecycle events of workspaces,
+// and reports their status in the conditions of the workspaces.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/conditions"
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Event is a lifecycle event of a workspace
+type Event string
+
+const (
+	PreStart  Event = "preStart"
+	PostStart Event = "postStart"
+	PreStop   Event = "preStop"
+	PostStop  Event = "postStop"
+)
+
+// Reasons of the conditions that report the status of the commands
+const (
+	CommandRunningReason   = "CommandRunning"
+	CommandSucceededReason = "CommandSucceeded"
+	CommandFailedReason    = "CommandFailed"
+)
+
+// CommandConditionType returns the type of the workspace condition that reports the status of a command
+// run on a lifecycle event.
+func CommandConditionType(commandId string) workspaces.WorkspaceConditionType {
+	return workspaces.WorkspaceConditionType("Command/" + commandId)
+}
+
+// Executor runs the commands of the lifecycle events in a workspace.
+type Executor interface {
+	// Apply applies a component targeted by an `apply` command, and returns once the component is applied.
+	//
+	// Container components run to completion: on the `preStart` event, they typically run as init containers
+	// of the workspace pod, and otherwise as jobs. Kubernetes and OpenShift components are created.
+	Apply(ctx context.Context, event Event, component workspaces.Component) error
+
+	// Exec runs the command line of an `exec` command in its container component,
+	// and returns an error if the command fails.
+	Exec(ctx context.Context, container workspaces.Component, command workspaces.ExecCommand) error
+}
+
+// Runner runs the commands bound to the lifecycle events of a flattened workspace template.
+type Runner struct {
+	content  *workspaces.DevWorkspaceTemplateSpecContent
+	executor Executor
+	commands map[string]workspaces.Command
+	// Guards the workspace status, updated by the commands of parallel composite commands
+	statusLock sync.Mutex
+}
+
+// NewRunner returns a runner of the lifecycle events of the given flattened workspace template,
+// that runs the commands with the given executor.
+func NewRunner(content *workspaces.DevWorkspaceTemplateSpecContent, executor Executor) *Runner {
+	runner := &Runner{
+		content:  content,
+		executor: executor,
+		commands: map[string]workspaces.Command{},
+	}
+	for _, command := range content.Commands {
+		runner.commands[command.Id] = command
+	}
+	return runner
+}
+
+// Run runs the commands bound to a lifecycle event, one after the other, and stops at the first failing command.
+// Composite commands run their commands one after the other, or in parallel when they are parallel:
+// the first failing command of a parallel composite command cancels the context of the other ones.
+//
+// - `apply` commands can run on all the events,
+//
+// - `exec` commands can only run on the `postStart` and `preStop` events, since the containers
+// are not running on the other events,
+//
+// - other types of commands are not supported.
+//
+// The status of each command is reported in the workspace status, in the condition of type `CommandConditionType(id)`.
+func (r *Runner) Run(ctx context.Context, event Event, status *workspaces.DevWorkspaceStatus) error {
+	for _, id := range r.eventCommands(event) {
+		if err := r.runCommand(ctx, event, id, nil, status); err != nil {
+			return fmt.Errorf("the '%s' event failed: %v", event, err)
+		}
+	}
+	return nil
+}
+
+func (r *Runner) eventCommands(event Event) []string {
+	if r.content.Events == nil {
+		return nil
+	}
+	switch event {
+	case PreStart:
+		return r.content.Events.PreStart
+	case PostStart:
+		return r.content.Events.PostStart
+	case PreStop:
+		return r.content.Events.PreStop
+	case PostStop:
+		return r.content.Events.PostStop
+	}
+	return nil
+}
+
+func (r *Runner) runCommand(ctx context.Context, event Event, id string, parents []string, status *workspaces.DevWorkspaceStatus) error {
+	for _, parent := range parents {
+		if parent == id {
+			return fmt.Errorf("composite commands have a cycle: %s -> %s", strings.Join(parents, " -> "), id)
+		}
+	}
+	command, exists := r.commands[id]
+	if !exists {
+		if len(parents) > 0 {
+			return fmt.Errorf("composite command '%s' references command '%s', which doesn't exist", parents[len(parents)-1], id)
+		}
+		return fmt.Errorf("command '%s' doesn't exist", id)
+	}
+
+	r.setCondition(status, id, corev1.ConditionUnknown, CommandRunningReason, "")
+	err := r.executeCommand(ctx, event, command, parents, status)
+	if err != nil {
+		r.setCondition(status, id, corev1.ConditionFalse, CommandFailedReason, err.Error())
+		return err
+	}
+	r.setCondition(status, id, corev1.ConditionTrue, CommandSucceededReason, "")
+	return nil
+}
+
+func (r *Runner) executeCommand(ctx context.Context, event Event, command workspaces.Command, parents []string, status *workspaces.DevWorkspaceStatus) error {
+	switch {
+	case command.Apply != nil:
+		component, err := r.component(command.Id, command.Apply.Component)
+		if err != nil {
+			return err
+		}
+		if err := r.executor.Apply(ctx, event, *component); err != nil {
+			return fmt.Errorf("command '%s' failed to apply component '%s': %v", command.Id, component.Name, err)
+		}
+		return nil
+	case command.Exec != nil:
+		if event != PostStart && event != PreStop {
+			return fmt.Errorf("command '%s' cannot run on the '%s' event, since exec commands need running containers", command.Id, event)
+		}
+		container, err := r.component(command.Id, command.Exec.Component)
+		if err != nil {
+			return err
+		}
+		if container.Container == nil {
+			return fmt.Errorf("command '%s' runs in component '%s', which is not a container component", command.Id, container.Name)
+		}
+		if err := r.executor.Exec(ctx, *container, *command.Exec); err != nil {
+			return fmt.Errorf("command '%s' failed: %v", command.Id, err)
+		}
+		return nil
+	case command.Composite != nil:
+		parents = append(parents, command.Id)
+		if !command.Composite.Parallel {
+			for _, subCommand := range command.Composite.Commands {
+				if err := r.runCommand(ctx, event, subCommand, parents, status); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		// The first failure cancels the other commands, whose errors are then not reported
+		parallelCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var errors *multierror.Error
+		var errorsLock sync.Mutex
+		var wg sync.WaitGroup
+		for _, subCommand := range command.Composite.Commands {
+			wg.Add(1)
+			go func(subCommand string) {
+				defer wg.Done()
+				if err := r.runCommand(parallelCtx, event, subCommand, append([]string{}, parents...), status); err != nil {
+					errorsLock.Lock()
+					if errors == nil {
+						errors = multierror.Append(errors, err)
+						cancel()
+					}
+					errorsLock.Unlock()
+				}
+			}(subCommand)
+		}
+		wg.Wait()
+		return errors.ErrorOrNil()
+	}
+	return fmt.Errorf("command '%s' cannot run on lifecycle events, since only apply, exec and composite commands are supported", command.Id)
+}
+
+func (r *Runner) component(commandId string, name string) (*workspaces.Component, error) {
+	for i := range r.content.Components {
+		if r.content.Components[i].Name == name {
+			return &r.content.Components[i], nil
+		}
+	}
+	return nil, fmt.Errorf("command '%s' references component '%s', which doesn't exist", commandId, name)
+}
+
+func (r *Runner) setCondition(status *workspaces.DevWorkspaceStatus, id string, conditionStatus corev1.ConditionStatus, reason string, message string) {
+	r.statusLock.Lock()
+	defer r.statusLock.Unlock()
+	conditions.SetCondition(status, workspaces.WorkspaceCondition{
+		Type:    CommandConditionType(id),
+		Status:  conditionStatus,
+		Reason:  reason,
+		Message: message,
+	})
+}
@@ -0,0 +1,299 @@
+package lifecycle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/conditions"
+	"github.com/devfile/api/pkg/utils/execution"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const template = `
+components:
+  - name: tools
+    container:
+      image: quay.io/example/tools:latest
+  - name: init-db
+    container:
+      image: quay.io/example/init-db:latest
+  - name: certificates
+    kubernetes:
+      inlined: |
+        kind: Secret
+  - name: sources
+    volume: {}
+commands:
+  - id: init-db
+    apply:
+      component: init-db
+  - id: create-certificates
+    apply:
+      component: certificates
+  - id: install
+    exec:
+      component: tools
+      commandLine: npm install
+  - id: warm-cache
+    exec:
+      component: tools
+      commandLine: npm run warm-cache
+  - id: setup
+    composite:
+      commands: [install, warm-cache]
+  - id: setup-parallel
+    composite:
+      parallel: true
+      commands: [install, warm-cache]
+  - id: cycle
+    composite:
+      commands: [install, nested-cycle]
+  - id: nested-cycle
+    composite:
+      commands: [cycle]
+  - id: missing-command
+    composite:
+      commands: [unknown]
+  - id: in-volume
+    exec:
+      component: sources
+      commandLine: ls
+  - id: vscode
+    vscodeTask:
+      inlined: "{}"
+`
+
+// waitForCancellation makes the fake executor wait for the context to be done, and return its error
+var waitForCancellation = fmt.Errorf("wait for cancellation")
+
+// fakeExecutor records the components it applies and the commands it runs,
+// and fails for the components and command lines in `failures`
+type fakeExecutor struct {
+	lock     sync.Mutex
+	calls    []string
+	failures map[string]error
+}
+
+func (f *fakeExecutor) Apply(ctx context.Context, event Event, component workspaces.Component) error {
+	return f.record(ctx, fmt.Sprintf("apply %s on %s", component.Name, event), component.Name)
+}
+
+func (f *fakeExecutor) Exec(ctx context.Context, container workspaces.Component, command workspaces.ExecCommand) error {
+	return f.record(ctx, fmt.Sprintf("exec '%s' in %s", command.CommandLine, container.Name), command.CommandLine)
+}
+
+func (f *fakeExecutor) record(ctx context.Context, call string, key string) error {
+	f.lock.Lock()
+	f.calls = append(f.calls, call)
+	err := f.failures[key]
+	f.lock.Unlock()
+	if err == waitForCancellation {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return err
+}
+
+func runEvent(t *testing.T, events workspaces.Events, event Event, failures map[string]error) (*fakeExecutor, *workspaces.DevWorkspaceStatus, error) {
+	content := &workspaces.DevWorkspaceTemplateSpecContent{}
+	if err := yaml.Unmarshal([]byte(template), content); err != nil {
+		t.Fatal(err)
+	}
+	content.Events = &events
+	executor := &fakeExecutor{failures: failures}
+	status := &workspaces.DevWorkspaceStatus{}
+	err := NewRunner(content, executor).Run(context.Background(), event, status)
+	return executor, status, err
+}
+
+func commandStatus(status *workspaces.DevWorkspaceStatus, id string) corev1.ConditionStatus {
+	condition := conditions.GetCondition(status, CommandConditionType(id))
+	if condition == nil {
+		return ""
+	}
+	return condition.Status
+}
+
+func TestRunPreStart(t *testing.T) {
+	executor, status, err := runEvent(t, workspaces.Events{
+		WorkspaceEvents: workspaces.WorkspaceEvents{
+			PreStart: []string{"create-certificates", "init-db"},
+		},
+	}, PreStart, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"apply certificates on preStart", "apply init-db on preStart"}, executor.calls)
+	assert.Equal(t, corev1.ConditionTrue, commandStatus(status, "create-certificates"))
+	assert.Equal(t, corev1.ConditionTrue, commandStatus(status, "init-db"))
+}
+
+func TestRunComposite(t *testing.T) {
+	executor, status, err := runEvent(t, workspaces.Events{
+		WorkspaceEvents: workspaces.WorkspaceEvents{
+			PostStart: []string{"setup"},
+		},
+	}, PostStart, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"exec 'npm install' in tools", "exec 'npm run warm-cache' in tools"}, executor.calls)
+	for _, id := range []string{"setup", "install", "warm-cache"} {
+		assert.Equal(t, corev1.ConditionTrue, commandStatus(status, id), id)
+	}
+}
+
+func TestRunParallelComposite(t *testing.T) {
+	executor, status, err := runEvent(t, workspaces.Events{
+		WorkspaceEvents: workspaces.WorkspaceEvents{
+			PostStart: []string{"setup-parallel"},
+		},
+	}, PostStart, map[string]error{"npm install": fmt.Errorf("exit status 1")})
+	assert.EqualError(t, err, "the 'postStart' event failed: 1 error occurred:\n\t* command 'install' failed: exit status 1\n\n")
+	sort.Strings(executor.calls)
+	assert.Equal(t, []string{"exec 'npm install' in tools", "exec 'npm run warm-cache' in tools"}, executor.calls)
+	assert.Equal(t, corev1.ConditionFalse, commandStatus(status, "setup-parallel"))
+	assert.Equal(t, corev1.ConditionFalse, commandStatus(status, "install"))
+	assert.Equal(t, corev1.ConditionTrue, commandStatus(status, "warm-cache"))
+	assert.Equal(t, "command 'install' failed: exit status 1", conditions.GetCondition(status, CommandConditionType("install")).Message)
+	assert.Equal(t, CommandFailedReason, conditions.GetCondition(status, CommandConditionType("install")).Reason)
+}
+
+func TestRunParallelCompositeCancelsOnFailure(t *testing.T) {
+	_, status, err := runEvent(t, workspaces.Events{
+		WorkspaceEvents: workspaces.WorkspaceEvents{
+			PostStart: []string{"setup-parallel"},
+		},
+	}, PostStart, map[string]error{"npm install": fmt.Errorf("exit status 1"), "npm run warm-cache": waitForCancellation})
+	assert.EqualError(t, err, "the 'postStart' event failed: 1 error occurred:\n\t* command 'install' failed: exit status 1\n\n")
+	assert.Equal(t, corev1.ConditionFalse, commandStatus(status, "install"))
+	assert.Equal(t, corev1.ConditionFalse, commandStatus(status, "warm-cache"))
+	assert.Equal(t, "command 'warm-cache' failed: context canceled", conditions.GetCondition(status, CommandConditionType("warm-cache")).Message)
+}
+
+func TestRunStopsAtFirstFailure(t *testing.T) {
+	executor, status, err := runEvent(t, workspaces.Events{
+		WorkspaceEvents: workspaces.WorkspaceEvents{
+			PreStop: []string{"install", "warm-cache"},
+		},
+	}, PreStop, map[string]error{"npm install": fmt.Errorf("exit status 1")})
+	assert.EqualError(t, err, "the 'preStop' event failed: command 'install' failed: exit status 1")
+	assert.Equal(t, []string{"exec 'npm install' in tools"}, executor.calls)
+	assert.Equal(t, corev1.ConditionFalse, commandStatus(status, "install"))
+	assert.Equal(t, corev1.ConditionStatus(""), commandStatus(status, "warm-cache"))
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    Event
+		commands []string
+		err      string
+	}{
+		{
+			name:     "exec on preStart",
+			event:    PreStart,
+			commands: []string{"install"},
+			err:      "the 'preStart' event failed: command 'install' cannot run on the 'preStart' event, since exec commands need running containers",
+		},
+		{
+			name:     "exec on postStop",
+			event:    PostStop,
+			commands: []string{"setup"},
+			err:      "the 'postStop' event failed: command 'install' cannot run on the 'postStop' event, since exec commands need running containers",
+		},
+		{
+			name:     "cycle",
+			event:    PostStart,
+			commands: []string{"cycle"},
+			err:      "the 'postStart' event failed: composite commands have a cycle: cycle -> nested-cycle -> cycle",
+		},
+		{
+			name:     "missing command",
+			event:    PostStart,
+			commands: []string{"unknown"},
+			err:      "the 'postStart' event failed: command 'unknown' doesn't exist",
+		},
+		{
+			name:     "missing command in composite",
+			event:    PostStart,
+			commands: []string{"missing-command"},
+			err:      "the 'postStart' event failed: composite command 'missing-command' references command 'unknown', which doesn't exist",
+		},
+		{
+			name:     "exec in a volume",
+			event:    PostStart,
+			commands: []string{"in-volume"},
+			err:      "the 'postStart' event failed: command 'in-volume' runs in component 'sources', which is not a container component",
+		},
+		{
+			name:     "unsupported command",
+			event:    PostStart,
+			commands: []string{"vscode"},
+			err:      "the 'postStart' event failed: command 'vscode' cannot run on lifecycle events, since only apply, exec and composite commands are supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := workspaces.Events{}
+			switch tt.event {
+			case PreStart:
+				events.PreStart = tt.commands
+			case PostStart:
+				events.PostStart = tt.commands
+			case PostStop:
+				events.PostStop = tt.commands
+			}
+			_, _, err := runEvent(t, events, tt.event, nil)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestCommandExecutor(t *testing.T) {
+	content := &workspaces.DevWorkspaceTemplateSpecContent{}
+	if err := yaml.Unmarshal([]byte(template), content); err != nil {
+		t.Fatal(err)
+	}
+	content.Events = &workspaces.Events{
+		WorkspaceEvents: workspaces.WorkspaceEvents{
+			PreStart:  []string{"init-db"},
+			PostStart: []string{"setup"},
+		},
+	}
+	commands := &execution.FakeExecutor{Results: map[string]execution.FakeResult{
+		"npm install":        {Stdout: "added 42 packages"},
+		"npm run warm-cache": {ExitCode: 2},
+	}}
+	var applied []string
+	var stdout bytes.Buffer
+	executor := &CommandExecutor{
+		Executor: commands,
+		Applier: ApplierFunc(func(ctx context.Context, event Event, component workspaces.Component) error {
+			applied = append(applied, fmt.Sprintf("apply %s on %s", component.Name, event))
+			return nil
+		}),
+		Streams: func(container workspaces.Component, command workspaces.ExecCommand) execution.Streams {
+			return execution.Streams{Stdout: &stdout}
+		},
+	}
+	runner := NewRunner(content, executor)
+	status := &workspaces.DevWorkspaceStatus{}
+
+	assert.NoError(t, runner.Run(context.Background(), PreStart, status))
+	assert.Equal(t, []string{"apply init-db on preStart"}, applied)
+
+	err := runner.Run(context.Background(), PostStart, status)
+	assert.EqualError(t, err, "the 'postStart' event failed: command 'warm-cache' failed: command exited with the 2 status")
+	assert.Equal(t, "added 42 packages", stdout.String())
+	assert.Equal(t, []execution.Execution{
+		{Component: "tools", Command: *runner.commands["install"].Exec},
+		{Component: "tools", Command: *runner.commands["warm-cache"].Exec},
+	}, commands.Executions())
+
+	err = (&CommandExecutor{Executor: commands}).Apply(context.Background(), PreStart, content.Components[1])
+	assert.EqualError(t, err, "applying components is not supported")
+}
@@ -0,0 +1,86 @@
+package manifests
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateEndpoints checks that each endpoint declared on the component
+// targets a port that is exposed by the manifest objects, either as the `port` or `targetPort`
+// of a `Service`, or as a `containerPort` of a container defined in a workload object
+// (`Pod`, `Deployment`, `DeploymentConfig`, `Job`, ...).
+func ValidateEndpoints(manifest *Manifest) error {
+	if len(manifest.Endpoints) == 0 {
+		return nil
+	}
+
+	ports := map[int64]bool{}
+	for _, object := range manifest.Objects {
+		content, err := toUnstructuredContent(object)
+		if err != nil {
+			return err
+		}
+		collectPorts(content, ports)
+	}
+
+	var errors *multierror.Error
+	for _, endpoint := range manifest.Endpoints {
+		if !ports[int64(endpoint.TargetPort)] {
+			errors = multierror.Append(errors, fmt.Errorf("endpoint '%s' of component '%s' targets port %d, which is not exposed by any Service or container of the manifest",
+				endpoint.Name, manifest.ComponentName, endpoint.TargetPort))
+		}
+	}
+	return errors.ErrorOrNil()
+}
+
+func toUnstructuredContent(object runtime.Object) (map[string]interface{}, error) {
+	if u, isUnstructured := object.(*unstructured.Unstructured); isUnstructured {
+		return u.Object, nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+}
+
+// collectPorts walks through the whole object content and records
+// all the port numbers found in `ports` lists.
+func collectPorts(value interface{}, ports map[int64]bool) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, elem := range typed {
+			if list, isList := elem.([]interface{}); isList && key == "ports" {
+				for _, port := range list {
+					if portMap, isMap := port.(map[string]interface{}); isMap {
+						for _, field := range []string{"containerPort", "port", "targetPort"} {
+							if number, isNumber := toInt64(portMap[field]); isNumber {
+								ports[number] = true
+							}
+						}
+					}
+				}
+				continue
+			}
+			collectPorts(elem, ports)
+		}
+	case []interface{}:
+		for _, elem := range typed {
+			collectPorts(elem, ports)
+		}
+	}
+}
+
+func toInt64(value interface{}) (int64, bool) {
+	switch number := value.(type) {
+	case int64:
+		return number, true
+	case int32:
+		return int64(number), true
+	case int:
+		return int64(number), true
+	case float64:
+		return int64(number), true
+	default:
+		return 0, false
+	}
+}
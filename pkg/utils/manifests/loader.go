@@ -0,0 +1,266 @@
+package manifests
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/fetching"
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Manifest contains the Kubernetes or OpenShift objects
+// loaded for a given `Kubernetes` or `Openshift` component.
+type Manifest struct {
+	// Name of the component the objects were loaded from
+	ComponentName string
+
+	// Type of the component the objects were loaded from
+	ComponentType workspaces.ComponentType
+
+	// Endpoints declared on the component
+	Endpoints []workspaces.Endpoint
+
+	// Objects defined in the component manifest, in the order of the manifest documents.
+	// Objects whose kind is registered in the `Loader` scheme are decoded into their typed structs,
+	// other objects are provided as `*unstructured.Unstructured`.
+	Objects []runtime.Object
+}
+
+// Loader loads the manifests of `Kubernetes` and `Openshift` components,
+// either fetched from their `Uri` or read from their `Inlined` content.
+type Loader struct {
+	// Scheme used to decode the manifest objects into typed `runtime.Object`s
+	Scheme *runtime.Scheme
+
+	// Fetcher used to retrieve the manifests referenced by `Uri`.
+	// When nil, `fetching.DefaultFetcher` is used.
+	Fetcher fetching.Fetcher
+
+	// Uri of the devfile that contains the components.
+	// Relative component uris are resolved against it.
+	BaseURI string
+
+	// Values of the parameters used when processing OpenShift `Template` objects.
+	// They take precedence over the values defined in the templates.
+	TemplateParameters map[string]string
+}
+
+// NewLoader creates a `Loader` that decodes manifest objects with the given scheme
+// and fetches remote manifests with the `fetching.DefaultFetcher`.
+func NewLoader(scheme *runtime.Scheme) *Loader {
+	return &Loader{
+		Scheme:  scheme,
+		Fetcher: fetching.DefaultFetcher,
+	}
+}
+
+// LoadComponents loads the manifests of all the `Kubernetes` and `Openshift` components
+// of a devfile, and validates that their declared endpoints match the content of the manifests.
+//
+// The returned manifests are in the same order as the components.
+func (l *Loader) LoadComponents(content *workspaces.DevWorkspaceTemplateSpecContent) ([]*Manifest, error) {
+	var errors *multierror.Error
+	manifests := []*Manifest{}
+	for _, component := range content.Components {
+		if component.Kubernetes == nil && component.Openshift == nil {
+			continue
+		}
+		manifest, err := l.LoadComponent(component)
+		if err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+		if err := ValidateEndpoints(manifest); err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+		manifests = append(manifests, manifest)
+	}
+	if err := errors.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return manifests, nil
+}
+
+// LoadComponent loads the manifest of a `Kubernetes` or `Openshift` component.
+//
+// For `Openshift` components, `Template` objects are processed with the `Loader` template parameters,
+// and replaced by the objects they contain.
+func (l *Loader) LoadComponent(component workspaces.Component) (*Manifest, error) {
+	var manifest *Manifest
+	err := component.Visit(workspaces.ComponentVisitor{
+		Kubernetes: func(kubernetes *workspaces.KubernetesComponent) error {
+			objects, err := l.load(kubernetes.K8sLikeComponent, false)
+			if err != nil {
+				return err
+			}
+			manifest = &Manifest{
+				ComponentType: workspaces.KubernetesComponentType,
+				Endpoints:     kubernetes.Endpoints,
+				Objects:       objects,
+			}
+			return nil
+		},
+		Openshift: func(openshift *workspaces.OpenshiftComponent) error {
+			objects, err := l.load(openshift.K8sLikeComponent, true)
+			if err != nil {
+				return err
+			}
+			manifest = &Manifest{
+				ComponentType: workspaces.OpenshiftComponentType,
+				Endpoints:     openshift.Endpoints,
+				Objects:       objects,
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load component '%s': %v", component.Name, err)
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("component '%s' is neither a Kubernetes nor an OpenShift component", component.Name)
+	}
+	manifest.ComponentName = component.Name
+	return manifest, nil
+}
+
+func (l *Loader) load(component workspaces.K8sLikeComponent, processTemplates bool) ([]runtime.Object, error) {
+	var content []byte
+	err := component.K8sLikeComponentLocation.Visit(workspaces.K8sLikeComponentLocationVisitor{
+		Uri: func(uri string) error {
+			resolved, err := fetching.ResolveURI(l.BaseURI, uri)
+			if err != nil {
+				return err
+			}
+			fetcher := l.Fetcher
+			if fetcher == nil {
+				fetcher = fetching.DefaultFetcher
+			}
+			content, err = fetcher.Fetch(resolved)
+			return err
+		},
+		Inlined: func(inlined string) error {
+			content = []byte(inlined)
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, fmt.Errorf("neither uri nor inlined manifest is set")
+	}
+
+	documents, err := SplitDocuments(content)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []runtime.Object{}
+	for _, document := range documents {
+		decoded, err := l.decode(document, processTemplates)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, nil
+}
+
+func (l *Loader) decode(document []byte, processTemplates bool) ([]runtime.Object, error) {
+	json, err := yaml.ToJSON(document)
+	if err != nil {
+		return nil, err
+	}
+	generic := &unstructured.Unstructured{}
+	if err := generic.UnmarshalJSON(json); err != nil {
+		return nil, err
+	}
+
+	if generic.IsList() {
+		list, err := generic.ToList()
+		if err != nil {
+			return nil, err
+		}
+		objects := []runtime.Object{}
+		for i := range list.Items {
+			itemJson, err := list.Items[i].MarshalJSON()
+			if err != nil {
+				return nil, err
+			}
+			decoded, err := l.decode(itemJson, processTemplates)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, decoded...)
+		}
+		return objects, nil
+	}
+
+	if processTemplates && isTemplate(generic) {
+		processed, err := processTemplate(generic, l.TemplateParameters)
+		if err != nil {
+			return nil, err
+		}
+		objects := []runtime.Object{}
+		for _, processedJson := range processed {
+			decoded, err := l.decode(processedJson, false)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, decoded...)
+		}
+		return objects, nil
+	}
+
+	if l.Scheme == nil || !l.Scheme.Recognizes(generic.GroupVersionKind()) {
+		return []runtime.Object{generic}, nil
+	}
+	decoder := serializer.NewCodecFactory(l.Scheme).UniversalDeserializer()
+	typed, _, err := decoder.Decode(json, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return []runtime.Object{typed}, nil
+}
+
+// SplitDocuments splits a multi-document YAML content into its documents.
+// Empty documents are ignored.
+func SplitDocuments(content []byte) ([][]byte, error) {
+	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	documents := [][]byte{}
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isEmptyDocument(document) {
+			continue
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// isEmptyDocument returns true if the document only contains
+// blank lines, comments or document separators.
+func isEmptyDocument(document []byte) bool {
+	for _, line := range bytes.Split(document, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) == 0 || bytes.HasPrefix(trimmed, []byte("#")) || bytes.Equal(trimmed, []byte("---")) {
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,169 @@
+package manifests
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func readFixture(t *testing.T, name string) string {
+	content, err := ioutil.ReadFile("test-fixtures/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func kubernetesComponent(name string, location workspaces.K8sLikeComponentLocation, endpoints ...workspaces.Endpoint) workspaces.Component {
+	return workspaces.Component{
+		Name: name,
+		ComponentUnion: workspaces.ComponentUnion{
+			Kubernetes: &workspaces.KubernetesComponent{
+				K8sLikeComponent: workspaces.K8sLikeComponent{
+					K8sLikeComponentLocation: location,
+					Endpoints:                endpoints,
+				},
+			},
+		},
+	}
+}
+
+func TestLoadInlinedMultiDocumentManifest(t *testing.T) {
+	component := kubernetesComponent("database", workspaces.K8sLikeComponentLocation{
+		Inlined: readFixture(t, "deployment-and-service.yaml"),
+	})
+
+	manifest, err := NewLoader(scheme.Scheme).LoadComponent(component)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "database", manifest.ComponentName)
+	assert.Equal(t, workspaces.KubernetesComponentType, manifest.ComponentType)
+	if assert.Len(t, manifest.Objects, 3) {
+		assert.IsType(t, &appsv1.Deployment{}, manifest.Objects[0])
+		assert.IsType(t, &corev1.Service{}, manifest.Objects[1])
+		assert.IsType(t, &unstructured.Unstructured{}, manifest.Objects[2])
+		assert.Equal(t, "Backup", manifest.Objects[2].GetObjectKind().GroupVersionKind().Kind)
+	}
+}
+
+func TestLoadManifestFromUri(t *testing.T) {
+	manifestContent := readFixture(t, "deployment-and-service.yaml")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/manifests/database.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(manifestContent))
+	}))
+	defer server.Close()
+
+	loader := NewLoader(scheme.Scheme)
+	loader.BaseURI = server.URL + "/devfile.yaml"
+	component := kubernetesComponent("database", workspaces.K8sLikeComponentLocation{
+		Uri: "manifests/database.yaml",
+	})
+
+	manifest, err := loader.LoadComponent(component)
+	assert.NoError(t, err)
+	assert.Len(t, manifest.Objects, 3)
+
+	component.Kubernetes.Uri = "manifests/unknown.yaml"
+	_, err = loader.LoadComponent(component)
+	assert.Error(t, err)
+}
+
+func TestProcessOpenshiftTemplate(t *testing.T) {
+	component := workspaces.Component{
+		Name: "redis",
+		ComponentUnion: workspaces.ComponentUnion{
+			Openshift: &workspaces.OpenshiftComponent{
+				K8sLikeComponent: workspaces.K8sLikeComponent{
+					K8sLikeComponentLocation: workspaces.K8sLikeComponentLocation{
+						Inlined: readFixture(t, "template.yaml"),
+					},
+				},
+			},
+		},
+	}
+
+	loader := NewLoader(scheme.Scheme)
+	_, err := loader.LoadComponent(component)
+	assert.EqualError(t, err, "failed to load component 'redis': required parameter 'PORT' of template 'redis' has no value")
+
+	loader.TemplateParameters = map[string]string{
+		"NAME": "cache",
+		"PORT": "6379",
+	}
+	manifest, err := loader.LoadComponent(component)
+	assert.NoError(t, err)
+	if assert.Len(t, manifest.Objects, 2) {
+		service, isService := manifest.Objects[0].(*corev1.Service)
+		if assert.True(t, isService) {
+			assert.Equal(t, "cache", service.Name)
+			assert.Equal(t, "cache", service.Spec.Selector["app"])
+			assert.Equal(t, int32(6379), service.Spec.Ports[0].Port)
+		}
+		deploymentConfig, isUnstructured := manifest.Objects[1].(*unstructured.Unstructured)
+		if assert.True(t, isUnstructured) {
+			replicas, _, _ := unstructured.NestedInt64(deploymentConfig.Object, "spec", "replicas")
+			assert.Equal(t, int64(1), replicas)
+			assert.Equal(t, "cache", deploymentConfig.GetName())
+		}
+	}
+}
+
+func TestTemplatesAreNotProcessedInKubernetesComponents(t *testing.T) {
+	component := kubernetesComponent("redis", workspaces.K8sLikeComponentLocation{
+		Inlined: readFixture(t, "template.yaml"),
+	})
+
+	manifest, err := NewLoader(scheme.Scheme).LoadComponent(component)
+	assert.NoError(t, err)
+	if assert.Len(t, manifest.Objects, 1) {
+		assert.Equal(t, "Template", manifest.Objects[0].GetObjectKind().GroupVersionKind().Kind)
+	}
+}
+
+func TestLoadComponentsValidatesEndpoints(t *testing.T) {
+	manifestContent := readFixture(t, "deployment-and-service.yaml")
+	content := &workspaces.DevWorkspaceTemplateSpecContent{
+		Components: []workspaces.Component{
+			{
+				Name: "tools",
+				ComponentUnion: workspaces.ComponentUnion{
+					Container: &workspaces.ContainerComponent{},
+				},
+			},
+			kubernetesComponent("database",
+				workspaces.K8sLikeComponentLocation{Inlined: manifestContent},
+				workspaces.Endpoint{Name: "postgres", TargetPort: 5432}),
+		},
+	}
+
+	manifests, err := NewLoader(scheme.Scheme).LoadComponents(content)
+	assert.NoError(t, err)
+	if assert.Len(t, manifests, 1) {
+		assert.Equal(t, "database", manifests[0].ComponentName)
+	}
+
+	content.Components[1].Kubernetes.Endpoints = append(content.Components[1].Kubernetes.Endpoints,
+		workspaces.Endpoint{Name: "admin", TargetPort: 8080})
+	_, err = NewLoader(scheme.Scheme).LoadComponents(content)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "endpoint 'admin' of component 'database' targets port 8080, which is not exposed by any Service or container of the manifest")
+	}
+}
+
+func TestSplitDocuments(t *testing.T) {
+	documents, err := SplitDocuments([]byte("---\n# only a comment\n---\na: b\n---\n\n---\nc: d\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a: b\n", "c: d\n"}, []string{string(documents[0]), string(documents[1])})
+}
@@ -0,0 +1,134 @@
+package manifests
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var (
+	// ${PARAMETER_NAME} is replaced by the string value of the parameter
+	stringParameterExpression = regexp.MustCompile(`\$\{([a-zA-Z0-9_]+)\}`)
+	// ${{PARAMETER_NAME}} is replaced by the JSON value of the parameter,
+	// when it is the whole content of a string field
+	jsonParameterExpression = regexp.MustCompile(`^\$\{\{([a-zA-Z0-9_]+)\}\}$`)
+)
+
+func isTemplate(object *unstructured.Unstructured) bool {
+	gvk := object.GroupVersionKind()
+	return gvk.Kind == "Template" &&
+		(gvk.Group == "template.openshift.io" || (gvk.Group == "" && gvk.Version == "v1"))
+}
+
+// processTemplate processes an OpenShift `Template` object in the same way as `oc process` does,
+// and returns the JSON content of the objects the template contains.
+//
+// Parameter values are taken from the `parameters` argument first, and then from the `value`
+// of the template parameters. Generated parameter values are not supported.
+func processTemplate(template *unstructured.Unstructured, parameters map[string]string) ([][]byte, error) {
+	templateParameters, _, err := unstructured.NestedSlice(template.Object, "parameters")
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	for _, p := range templateParameters {
+		parameter, isMap := p.(map[string]interface{})
+		if !isMap {
+			return nil, fmt.Errorf("invalid parameter in template '%s'", template.GetName())
+		}
+		name, _, _ := unstructured.NestedString(parameter, "name")
+		if name == "" {
+			return nil, fmt.Errorf("parameter without name in template '%s'", template.GetName())
+		}
+		value, _, _ := unstructured.NestedString(parameter, "value")
+		if provided, isProvided := parameters[name]; isProvided {
+			value = provided
+		}
+		if value == "" {
+			if generate, _, _ := unstructured.NestedString(parameter, "generate"); generate != "" {
+				return nil, fmt.Errorf("parameter '%s' of template '%s' should be generated, which is not supported: a value should be provided", name, template.GetName())
+			}
+			if required, _, _ := unstructured.NestedBool(parameter, "required"); required {
+				return nil, fmt.Errorf("required parameter '%s' of template '%s' has no value", name, template.GetName())
+			}
+		}
+		values[name] = value
+	}
+
+	objects, _, err := unstructured.NestedSlice(template.Object, "objects")
+	if err != nil {
+		return nil, err
+	}
+
+	result := [][]byte{}
+	for _, object := range objects {
+		substituted, err := substituteParameters(object, values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process template '%s': %v", template.GetName(), err)
+		}
+		objectJson, err := json.Marshal(substituted)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, objectJson)
+	}
+	return result, nil
+}
+
+func substituteParameters(value interface{}, parameters map[string]string) (interface{}, error) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for key, elem := range typed {
+			substitutedKey, err := substituteParameters(key, parameters)
+			if err != nil {
+				return nil, err
+			}
+			keyString, isString := substitutedKey.(string)
+			if !isString {
+				return nil, fmt.Errorf("parameter substitution in key '%s' should produce a string", key)
+			}
+			substituted, err := substituteParameters(elem, parameters)
+			if err != nil {
+				return nil, err
+			}
+			result[keyString] = substituted
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, 0, len(typed))
+		for _, elem := range typed {
+			substituted, err := substituteParameters(elem, parameters)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, substituted)
+		}
+		return result, nil
+	case string:
+		if match := jsonParameterExpression.FindStringSubmatch(typed); match != nil {
+			parameterValue, isDefined := parameters[match[1]]
+			if !isDefined {
+				return typed, nil
+			}
+			var jsonValue interface{}
+			if err := json.Unmarshal([]byte(parameterValue), &jsonValue); err != nil {
+				return nil, fmt.Errorf("value of parameter '%s' is not valid JSON: %v", match[1], err)
+			}
+			return jsonValue, nil
+		}
+		return stringParameterExpression.ReplaceAllStringFunc(typed, func(expression string) string {
+			name := strings.TrimSuffix(strings.TrimPrefix(expression, "${"), "}")
+			if parameterValue, isDefined := parameters[name]; isDefined {
+				return parameterValue
+			}
+			return expression
+		}), nil
+	default:
+		return value, nil
+	}
+}
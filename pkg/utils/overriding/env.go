@@ -0,0 +1,50 @@
+package overriding
+
+// exclusiveEnvValues adds to an overriding patch the directives that remove the previous `valueFrom` source
+// of the environment variables whose value is overridden, and conversely, since an environment variable
+// cannot have both a value and a `valueFrom` source. The build arguments of image components are handled the same way.
+func exclusiveEnvValues(patch map[string]interface{}) {
+	for _, component := range listOfMaps(patch["components"]) {
+		if container, isMap := component["container"].(map[string]interface{}); isMap {
+			exclusiveValues(container["env"])
+		}
+		if image, isMap := component["image"].(map[string]interface{}); isMap {
+			exclusiveValues(image["args"])
+		}
+		if plugin, isMap := component["plugin"].(map[string]interface{}); isMap {
+			exclusiveEnvValues(plugin)
+		}
+	}
+	for _, command := range listOfMaps(patch["commands"]) {
+		if exec, isMap := command["exec"].(map[string]interface{}); isMap {
+			exclusiveValues(exec["env"])
+		}
+	}
+}
+
+func exclusiveValues(env interface{}) {
+	for _, variable := range listOfMaps(env) {
+		_, hasValue := variable["value"]
+		_, hasValueFrom := variable["valueFrom"]
+		switch {
+		case hasValue && !hasValueFrom:
+			variable["valueFrom"] = nil
+		case hasValueFrom && !hasValue:
+			variable["value"] = nil
+		}
+	}
+}
+
+func listOfMaps(list interface{}) []map[string]interface{} {
+	items, isList := list.([]interface{})
+	if !isList {
+		return nil
+	}
+	var maps []map[string]interface{}
+	for _, item := range items {
+		if itemMap, isMap := item.(map[string]interface{}); isMap {
+			maps = append(maps, itemMap)
+		}
+	}
+	return maps
+}
@@ -21,11 +21,21 @@ func checkKeys(doCheck checkFn, toplevelListContainers ...workspaces.TopLevelLis
 	listTypeToKeys := map[string][]sets.String{}
 
 	// Flatten []map[string]KeyedList -> map[string][]KeyedList based on map keys and convert each KeyedList
-	// into a sets.String
-	for _, topLevelListContainer := range toplevelListContainers {
+	// into a sets.String.
+	// A container that doesn't contain a type of top-level list (for example plugin overrides have no projects)
+	// gets an empty set at its position, so that the sets always match the `toplevelListContainers`.
+	for index, topLevelListContainer := range toplevelListContainers {
 		topLevelList := topLevelListContainer.GetToplevelLists()
 		for listType, listElem := range topLevelList {
-			listTypeToKeys[listType] = append(listTypeToKeys[listType], sets.NewString(listElem.GetKeys()...))
+			keySets, exists := listTypeToKeys[listType]
+			if !exists {
+				keySets = make([]sets.String, len(toplevelListContainers))
+				for i := range keySets {
+					keySets[i] = sets.NewString()
+				}
+				listTypeToKeys[listType] = keySets
+			}
+			keySets[index] = sets.NewString(listElem.GetKeys()...)
 		}
 	}
 	for listType, keySets := range listTypeToKeys {
@@ -55,6 +55,9 @@ func OverrideDevWorkspaceTemplateSpecBytes(originalBytes []byte, patchBytes []by
 // https://github.com/kubernetes/community/blob/master/contributors/devel/sig-api-machinery/strategic-merge-patch.md#background
 //
 // The result is a transformed `DevfileWorkspaceTemplateSpec` object.
+//
+// When a volume is overridden with a different size, the larger of the two sizes is kept,
+// so that an override cannot shrink a volume below the size its parent or plugin requires.
 func OverrideDevWorkspaceTemplateSpec(original *workspaces.DevWorkspaceTemplateSpecContent, patch workspaces.Overrides) (*workspaces.DevWorkspaceTemplateSpecContent, error) {
 	if err := ensureOnlyExistingElementsAreOverridden(original, patch); err != nil {
 		return nil, err
@@ -85,6 +88,7 @@ func OverrideDevWorkspaceTemplateSpec(original *workspaces.DevWorkspaceTemplateS
 	if err != nil {
 		return nil, err
 	}
+	exclusiveEnvValues(patchMap)
 
 	schema, err := strategicpatch.NewPatchMetaFromStruct(original)
 	if err != nil {
@@ -107,6 +111,8 @@ func OverrideDevWorkspaceTemplateSpec(original *workspaces.DevWorkspaceTemplateS
 		return nil, err
 	}
 
+	keepLargerVolumeSizes(original, &patched)
+
 	if err = unions.Simplify(&patched); err != nil {
 		return nil, err
 	}
@@ -137,6 +137,59 @@ func TestBasicToplevelOverriding(t *testing.T) {
 	assert.Equal(t, expected, result, "The two values should be the same.")
 }
 
+func TestOverridingWithPluginOverrides(t *testing.T) {
+	original := workspaces.DevWorkspaceTemplateSpecContent{
+		Projects: []workspaces.Project{
+			{
+				Name: "app",
+			},
+		},
+		Components: []workspaces.Component{
+			{
+				Name: "tools",
+				ComponentUnion: workspaces.ComponentUnion{
+					Container: &workspaces.ContainerComponent{
+						Container: workspaces.Container{
+							Image:       "quay.io/example/tools:1.0",
+							MemoryLimit: "512Mi",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Plugin overrides have no projects
+	patch := workspaces.PluginOverrides{
+		Components: []workspaces.PluginComponentsOverride{
+			{
+				Name: "tools",
+				PluginComponentsOverrideUnion: workspaces.PluginComponentsOverrideUnion{
+					Container: &workspaces.ContainerComponent{
+						Container: workspaces.Container{
+							MemoryLimit: "1Gi",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := original.DeepCopy()
+	expected.Components[0].Container.MemoryLimit = "1Gi"
+
+	result, err := OverrideDevWorkspaceTemplateSpec(&original, &patch)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, result, "The two values should be the same.")
+	}
+
+	patch.Components[0].Name = "unknown"
+	_, err = OverrideDevWorkspaceTemplateSpec(&original, &patch)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Some Components do not override any existing element: unknown.")
+	}
+}
+
 func overridingPatchTest(original, patch, expected []byte, expectedError string) func(t *testing.T) {
 	return func(t *testing.T) {
 		result, err := OverrideDevWorkspaceTemplateSpecBytes(original, patch)
@@ -0,0 +1,40 @@
+package overriding
+
+import (
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// keepLargerVolumeSizes resolves the size conflicts between the volumes of the original content
+// and their overrides: the larger of the two sizes is kept, so that an override cannot shrink a volume
+// below the size its parent or plugin requires.
+//
+// Sizes that are not valid resource quantities are kept as overridden, and reported by the validation.
+func keepLargerVolumeSizes(original *workspaces.DevWorkspaceTemplateSpecContent, patched *workspaces.DevWorkspaceTemplateSpecContent) {
+	originalSizes := map[string]string{}
+	for _, component := range original.Components {
+		if component.Volume != nil && component.Volume.Size != "" {
+			originalSizes[component.Name] = component.Volume.Size
+		}
+	}
+	for _, component := range patched.Components {
+		if component.Volume == nil {
+			continue
+		}
+		originalSize, hasSize := originalSizes[component.Name]
+		if !hasSize {
+			continue
+		}
+		originalQuantity, err := resource.ParseQuantity(originalSize)
+		if err != nil {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(component.Volume.Size)
+		if err != nil {
+			continue
+		}
+		if originalQuantity.Cmp(quantity) > 0 {
+			component.Volume.Size = originalSize
+		}
+	}
+}
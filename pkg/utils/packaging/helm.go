@@ -0,0 +1,281 @@
+package packaging
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/manifests"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultChartVersion is the version of the generated Helm charts when no version is given
+const DefaultChartVersion = "0.1.0"
+
+// Chart is the content of the `Chart.yaml` file of a Helm chart
+type Chart struct {
+	APIVersion  string `json:"apiVersion"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type"`
+	Version     string `json:"version"`
+}
+
+// Values is the content of the `values.yaml` file of a generated Helm chart
+type Values struct {
+	// Values of the container components, by component name
+	Components map[string]ComponentValues `json:"components,omitempty"`
+}
+
+// ComponentValues are the values of a container component in a generated Helm chart
+type ComponentValues struct {
+	Image         string            `json:"image"`
+	MemoryLimit   string            `json:"memoryLimit,omitempty"`
+	MemoryRequest string            `json:"memoryRequest,omitempty"`
+	Env           map[string]string `json:"env,omitempty"`
+}
+
+// Helm hooks that correspond to the lifecycle events apply commands are bound to
+var helmHooks = map[string]string{
+	preStartEvent:  "pre-install,pre-upgrade",
+	postStartEvent: "post-install,post-upgrade",
+	preStopEvent:   "pre-delete",
+	postStopEvent:  "post-delete",
+}
+
+// HelmChart exports a flattened devfile as a Helm chart.
+//
+// Each generated object is a template of the chart, and the image, memory and environment variables
+// of the container components are values of the chart, in the `components.<component name>` value.
+// Environment variables whose values come from `valueFrom` sources are not values of the chart.
+//
+// Containers targeted by `apply` commands become jobs. When the command is bound to a lifecycle event,
+// the job, or the objects of the applied `Kubernetes` component, are annotated as the Helm hook
+// that corresponds to the event, weighted by the position of the command in the event bindings
+// and in the sequential composite commands that run it:
+// `preStart` commands become `pre-install` and `pre-upgrade` hooks, `postStart` commands
+// `post-install` and `post-upgrade` hooks, and `preStop` and `postStop` commands
+// `pre-delete` and `post-delete` hooks.
+//
+// Other inlined `Kubernetes` and `Openshift` manifests are included verbatim,
+// except for the template delimiters, which are escaped.
+// The claims of the volumes shared with the namespace are kept when the chart is uninstalled.
+// Returns warnings for the components that cannot be exported.
+func HelmChart(content *workspaces.DevWorkspaceTemplateSpecContent, options Options) (Files, []string, error) {
+	r, err := render(content, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version := options.Version
+	if version == "" {
+		version = DefaultChartVersion
+	}
+	files := Files{}
+	files["Chart.yaml"], err = yaml.Marshal(Chart{
+		APIVersion: "v2",
+		Name:       options.Name,
+		Type:       "application",
+		Version:    version,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := Values{}
+	for _, component := range r.containers {
+		container := component.Container
+		componentValues := ComponentValues{
+			Image:         container.Image,
+			MemoryLimit:   container.MemoryLimit,
+			MemoryRequest: container.MemoryRequest,
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil {
+				continue
+			}
+			if componentValues.Env == nil {
+				componentValues.Env = map[string]string{}
+			}
+			componentValues.Env[env.Name] = env.Value
+		}
+		if values.Components == nil {
+			values.Components = map[string]ComponentValues{}
+		}
+		values.Components[component.Name] = componentValues
+	}
+	if files["values.yaml"], err = yaml.Marshal(values); err != nil {
+		return nil, nil, err
+	}
+
+	for _, doc := range r.documents {
+		template, err := helmTemplate(doc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate the template of '%s': %v", doc.name, err)
+		}
+		files["templates/"+doc.name+".yaml"] = template
+	}
+	return files, r.warnings, nil
+}
+
+func helmTemplate(doc document) ([]byte, error) {
+	if doc.manifest != nil {
+		if doc.hook == nil || doc.hook.event == "" {
+			return escapeTemplateDelimiters(doc.manifest), nil
+		}
+		manifest, err := annotateManifest(doc.manifest, hookAnnotations(doc.hook))
+		if err != nil {
+			return nil, err
+		}
+		return escapeTemplateDelimiters(manifest), nil
+	}
+
+	if doc.hook != nil && doc.hook.event != "" {
+		annotate(doc.object, hookAnnotations(doc.hook))
+	}
+	if doc.shared {
+		// Other applications of the namespace may still use the object
+		annotate(doc.object, map[string]string{"helm.sh/resource-policy": "keep"})
+	}
+	references := &valueReferences{}
+	containers, _, _ := unstructured.NestedFieldNoCopy(doc.object, "spec", "template", "spec", "containers")
+	if containers, isList := containers.([]interface{}); isList {
+		for _, item := range containers {
+			podContainer, isMap := item.(map[string]interface{})
+			if !isMap {
+				continue
+			}
+			name, _ := podContainer["name"].(string)
+			if container, isTemplated := doc.containers[name]; isTemplated {
+				references.templateContainer(name, container, podContainer)
+			}
+		}
+	}
+	template, err := yaml.Marshal(doc.object)
+	if err != nil {
+		return nil, err
+	}
+	return references.replace(escapeTemplateDelimiters(template)), nil
+}
+
+// escapeTemplateDelimiters escapes the template delimiters found in the content of the devfile,
+// so that Helm renders them as-is instead of parsing them as template actions.
+func escapeTemplateDelimiters(content []byte) []byte {
+	return bytes.ReplaceAll(content, []byte("{{"), []byte(`{{ "{{" }}`))
+}
+
+// templateContainer replaces the image, memory and environment variables of a pod container
+// by references to the values of the corresponding devfile container.
+func (v *valueReferences) templateContainer(name string, container workspaces.Container, podContainer map[string]interface{}) {
+	podContainer["image"] = v.add(name, "image")
+
+	if resources, isMap := podContainer["resources"].(map[string]interface{}); isMap {
+		if limits, isMap := resources["limits"].(map[string]interface{}); isMap && container.MemoryLimit != "" {
+			limits["memory"] = v.add(name, "memoryLimit")
+		}
+		if requests, isMap := resources["requests"].(map[string]interface{}); isMap {
+			switch {
+			case container.MemoryRequest != "":
+				requests["memory"] = v.add(name, "memoryRequest")
+			case container.MemoryLimit != "":
+				requests["memory"] = v.add(name, "memoryLimit")
+			}
+		}
+	}
+
+	devfileEnv := map[string]bool{}
+	for _, env := range container.Env {
+		devfileEnv[env.Name] = env.ValueFrom == nil
+	}
+	env, _ := podContainer["env"].([]interface{})
+	for _, item := range env {
+		variable, isMap := item.(map[string]interface{})
+		if !isMap {
+			continue
+		}
+		if variableName, _ := variable["name"].(string); devfileEnv[variableName] {
+			variable["value"] = v.add(name, "env", variableName)
+		}
+	}
+}
+
+// valueReferences contains the template expressions of the values referenced in an object.
+// Until the object is marshaled, the references are replaced by placeholders,
+// so that the YAML marshaling neither quotes nor folds the template expressions.
+type valueReferences []string
+
+// add returns the placeholder of a reference to a value of a container component
+func (v *valueReferences) add(component string, keys ...string) string {
+	*v = append(*v, valueReference(component, keys...))
+	return placeholder(len(*v) - 1)
+}
+
+func placeholder(index int) string {
+	return fmt.Sprintf("__value_reference_%d__", index)
+}
+
+// replace replaces the placeholders by the template expressions in the marshaled object
+func (v valueReferences) replace(content []byte) []byte {
+	for i, reference := range v {
+		content = bytes.ReplaceAll(content, []byte(placeholder(i)), []byte(reference))
+	}
+	return content
+}
+
+// valueReference returns the template expression of a value of a container component
+func valueReference(component string, keys ...string) string {
+	quoted := []string{strconv.Quote(component)}
+	for _, key := range keys {
+		quoted = append(quoted, strconv.Quote(key))
+	}
+	return fmt.Sprintf("{{ index .Values.components %s | quote }}", strings.Join(quoted, " "))
+}
+
+func hookAnnotations(hook *applyHook) map[string]string {
+	return map[string]string{
+		"helm.sh/hook":               helmHooks[hook.event],
+		"helm.sh/hook-weight":        strconv.Itoa(hook.weight),
+		"helm.sh/hook-delete-policy": "before-hook-creation",
+	}
+}
+
+func annotate(object map[string]interface{}, annotations map[string]string) {
+	metadata, isMap := object["metadata"].(map[string]interface{})
+	if !isMap {
+		metadata = map[string]interface{}{}
+		object["metadata"] = metadata
+	}
+	existing, isMap := metadata["annotations"].(map[string]interface{})
+	if !isMap {
+		existing = map[string]interface{}{}
+		metadata["annotations"] = existing
+	}
+	for key, value := range annotations {
+		existing[key] = value
+	}
+}
+
+// annotateManifest adds the annotations to each object of a multi-document manifest
+func annotateManifest(manifest []byte, annotations map[string]string) ([]byte, error) {
+	documents, err := manifests.SplitDocuments(manifest)
+	if err != nil {
+		return nil, err
+	}
+	var annotated [][]byte
+	for _, document := range documents {
+		object := map[string]interface{}{}
+		if err := yaml.Unmarshal(document, &object); err != nil {
+			return nil, err
+		}
+		annotate(object, annotations)
+		content, err := yaml.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+		annotated = append(annotated, content)
+	}
+	return bytes.Join(annotated, []byte("---\n")), nil
+}
@@ -0,0 +1,56 @@
+package packaging
+
+import (
+	"fmt"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"sigs.k8s.io/yaml"
+)
+
+// Kustomization is the content of the `kustomization.yaml` file of a kustomize base
+type Kustomization struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Resources  []string `json:"resources"`
+}
+
+// KustomizeBase exports a flattened devfile as a kustomize base,
+// with a resource file per generated object and per inlined `Kubernetes` or `Openshift` manifest.
+// The image, memory and environment variables of the containers can be customized
+// with patches in the overlays that use the base.
+//
+// Containers targeted by `apply` commands become jobs. Since kustomize has no lifecycle hooks,
+// the event bindings of the apply commands are ignored, with a warning.
+// Inlined manifests are included verbatim.
+// Returns warnings for the components that cannot be exported.
+func KustomizeBase(content *workspaces.DevWorkspaceTemplateSpecContent, options Options) (Files, []string, error) {
+	r, err := render(content, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := Files{}
+	kustomization := Kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+	}
+	warnings := r.warnings
+	for _, doc := range r.documents {
+		fileName := doc.name + ".yaml"
+		kustomization.Resources = append(kustomization.Resources, fileName)
+		if doc.hook != nil && doc.hook.event != "" {
+			warnings = append(warnings, fmt.Sprintf("component '%s' is applied with the other resources, instead of on the '%s' event by command '%s', since kustomize has no lifecycle hooks", doc.hook.component, doc.hook.event, doc.hook.command))
+		}
+		if doc.manifest != nil {
+			files[fileName] = doc.manifest
+			continue
+		}
+		if files[fileName], err = yaml.Marshal(doc.object); err != nil {
+			return nil, nil, err
+		}
+	}
+	if files["kustomization.yaml"], err = yaml.Marshal(kustomization); err != nil {
+		return nil, nil, err
+	}
+	return files, warnings, nil
+}
@@ -0,0 +1,581 @@
+// Package packaging exports flattened devfiles as Helm charts or kustomize bases,
+// so that the "outer loop" environment of an application can be deployed
+// from the same devfile as its development workspace.
+package packaging
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/environment"
+	"github.com/devfile/api/pkg/utils/images"
+	"github.com/devfile/api/pkg/utils/probes"
+	"github.com/devfile/api/pkg/utils/resources"
+	"github.com/devfile/api/pkg/utils/validation"
+	"github.com/hashicorp/go-multierror"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Labels set on the generated objects
+const (
+	// Label that contains the name of the application, on all the generated objects
+	PartOfLabel = "app.kubernetes.io/part-of"
+	// Label that contains the name of the workload, on deployments, jobs and their pods
+	NameLabel = "app.kubernetes.io/name"
+)
+
+// DefaultVolumeSize is the size of the persistent volume claims of volume components without size
+const DefaultVolumeSize = "1Gi"
+
+// Options are the options of the export of a devfile as a Helm chart or a kustomize base
+type Options struct {
+	// Name of the application.
+	// It is the name of the Helm chart, the name of the deployment of the main workspace pod,
+	// and the prefix of the names of the other generated objects.
+	Name string
+
+	// Version of the generated Helm chart.
+	//
+	// Defaults to `0.1.0`
+	Version string
+}
+
+// Files are the files of an exported Helm chart or kustomize base,
+// by path relative to the root directory of the chart or base.
+type Files map[string][]byte
+
+// Paths returns the paths of the files, sorted.
+func (f Files) Paths() []string {
+	var paths []string
+	for p := range f {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Write writes the files in the given directory, creating the sub-directories as needed.
+func (f Files) Write(directory string) error {
+	for _, p := range f.Paths() {
+		target := filepath.Join(directory, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(target, f[p], 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// document is a file of the rendered workspace, which contains either generated objects or a verbatim manifest
+type document struct {
+	// Name of the file, without the `.yaml` extension
+	name string
+	// Generated object, converted to its unstructured content
+	object map[string]interface{}
+	// Content of an inlined `Kubernetes` or `Openshift` component, included as-is
+	manifest []byte
+	// Container components whose values are used in the object, by container name
+	containers map[string]workspaces.Container
+	// Lifecycle event of the command that applies this document, if any
+	hook *applyHook
+	// Whether the object is shared with the other applications of the namespace,
+	// and should be kept when the application is removed
+	shared bool
+}
+
+// applyHook is the binding of the `apply` command of a component to a lifecycle event
+type applyHook struct {
+	component string
+	event     string
+	command   string
+	// Position of the command in the event bindings
+	weight int
+}
+
+// Lifecycle events that apply commands can be bound to
+const (
+	preStartEvent  = "preStart"
+	postStartEvent = "postStart"
+	preStopEvent   = "preStop"
+	postStopEvent  = "postStop"
+)
+
+// rendering contains the objects rendered from the components of a devfile
+type rendering struct {
+	options   Options
+	documents []document
+	// Container components, in the order of the devfile, whose values can be customized
+	containers []workspaces.Component
+	warnings   []string
+}
+
+// render converts the components of a flattened devfile to Kubernetes objects:
+//
+// - the containers of the main workspace pod become a deployment, named after the application,
+//
+// - containers with a dedicated pod become their own deployment,
+//
+// - containers targeted by `apply` commands become jobs,
+//
+// - volume components become persistent volume claims, except ephemeral volumes, which become
+// `emptyDir` volumes of the pods,
+//
+// - endpoints that are exposed outside of their pod become services,
+//
+// - inlined `Kubernetes` and `Openshift` manifests are included as-is,
+//
+// - containers that run the image of an image component run the image name of the component.
+func render(content *workspaces.DevWorkspaceTemplateSpecContent, options Options) (*rendering, error) {
+	if options.Name == "" {
+		return nil, fmt.Errorf("a name is required to export a devfile")
+	}
+	r := &rendering{options: options}
+
+	hooks, err := applyHooks(content)
+	if err != nil {
+		return nil, err
+	}
+
+	projectSource := ""
+	if len(content.Projects) > 0 {
+		cloneDirectory, err := validation.CloneDirectory(content.Projects[0])
+		if err != nil {
+			return nil, err
+		}
+		projectSource = cloneDirectory
+	}
+
+	components := resolveImages(content.Components)
+	volumes := map[string]workspaces.Volume{}
+	var mainContainers []workspaces.Component
+	var errors *multierror.Error
+	for _, component := range components {
+		switch {
+		case component.Volume != nil:
+			volumes[component.Name] = component.Volume.Volume
+		case component.Container != nil:
+			r.containers = append(r.containers, component)
+			if hooks[component.Name] == nil && !component.Container.DedicatedPod {
+				mainContainers = append(mainContainers, component)
+			}
+		}
+	}
+
+	for _, build := range images.Builds(content.Components) {
+		r.warnings = append(r.warnings, fmt.Sprintf("image component '%s' is not built by the export, so image '%s' should be built and pushed before the deployment", build.Component, build.ImageName))
+	}
+
+	if len(mainContainers) > 0 {
+		if err := r.addWorkload(options.Name, "deployment", mainContainers, volumes, projectSource, nil); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+	}
+	for _, component := range components {
+		switch {
+		case component.Container != nil:
+			hook := hooks[component.Name]
+			if hook == nil && !component.Container.DedicatedPod {
+				continue
+			}
+			name := component.Name + "-deployment"
+			if hook != nil {
+				name = component.Name + "-job"
+			}
+			err := r.addWorkload(options.Name+"-"+component.Name, name, []workspaces.Component{component}, volumes, projectSource, hook)
+			if err != nil {
+				errors = multierror.Append(errors, err)
+			}
+		case component.Volume != nil:
+			volume := component.Volume.Volume
+			if volume.Ephemeral {
+				continue
+			}
+			claim, err := persistentVolumeClaim(claimName(options.Name, component.Name, volume), options.Name, volume)
+			if err != nil {
+				errors = multierror.Append(errors, fmt.Errorf("volume '%s': %v", component.Name, err))
+				continue
+			}
+			if err := r.addObject(component.Name+"-pvc", claim, nil, nil); err != nil {
+				errors = multierror.Append(errors, fmt.Errorf("volume '%s': %v", component.Name, err))
+				continue
+			}
+			r.documents[len(r.documents)-1].shared = volume.Sharing == workspaces.NamespaceVolumeSharing
+		case component.Kubernetes != nil:
+			r.addManifest(component.Name, component.Kubernetes.K8sLikeComponent, hooks[component.Name])
+		case component.Openshift != nil:
+			r.addManifest(component.Name, component.Openshift.K8sLikeComponent, hooks[component.Name])
+		case component.Custom != nil:
+			r.warnings = append(r.warnings, fmt.Sprintf("component '%s' is ignored, since custom components of class '%s' cannot be exported", component.Name, component.Custom.ComponentClass))
+		case component.Plugin != nil:
+			r.warnings = append(r.warnings, fmt.Sprintf("plugin '%s' is ignored, since the devfile should be flattened before the export", component.Name))
+		}
+	}
+
+	if err := errors.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// resolveImages returns a copy of the components, where the containers that run the image
+// of an image component run the image name of the component.
+func resolveImages(components []workspaces.Component) []workspaces.Component {
+	var resolved []workspaces.Component
+	for _, component := range components {
+		if component.Container != nil {
+			if imageName, build := images.ContainerImage(components, component.Container.Container); build != nil {
+				component = *component.DeepCopy()
+				component.Container.Image = imageName
+			}
+		}
+		resolved = append(resolved, component)
+	}
+	return resolved
+}
+
+// applyHooks returns the lifecycle event bindings of the components targeted by `apply` commands, by component name.
+// Components targeted by apply commands that are not bound to any event have a hook without event.
+func applyHooks(content *workspaces.DevWorkspaceTemplateSpecContent) (map[string]*applyHook, error) {
+	commands := map[string]workspaces.Command{}
+	for _, command := range content.Commands {
+		commands[command.Id] = command
+	}
+
+	hooks := map[string]*applyHook{}
+	// bind binds the components of the apply commands run by a command to the event, and returns the weight
+	// of the next command: the commands of sequential composite commands get increasing weights,
+	// and the commands of parallel composite commands the same weight.
+	var bind func(id string, event string, weight int, visited map[string]bool) (int, error)
+	bind = func(id string, event string, weight int, visited map[string]bool) (int, error) {
+		command, exists := commands[id]
+		if !exists {
+			return weight, fmt.Errorf("the '%s' event references command '%s', which doesn't exist", event, id)
+		}
+		if visited[id] {
+			return weight, nil
+		}
+		visited[id] = true
+		switch {
+		case command.Apply != nil:
+			if hooks[command.Apply.Component] == nil {
+				hooks[command.Apply.Component] = &applyHook{component: command.Apply.Component, event: event, command: id, weight: weight}
+			}
+			return weight + 1, nil
+		case command.Composite != nil:
+			next := weight
+			for _, subCommand := range command.Composite.Commands {
+				subWeight := next
+				if command.Composite.Parallel {
+					subWeight = weight
+				}
+				subNext, err := bind(subCommand, event, subWeight, visited)
+				if err != nil {
+					return weight, err
+				}
+				if subNext > next {
+					next = subNext
+				}
+			}
+			return next, nil
+		}
+		return weight, nil
+	}
+
+	if content.Events != nil {
+		for _, binding := range []struct {
+			event    string
+			commands []string
+		}{
+			{preStartEvent, content.Events.PreStart},
+			{postStartEvent, content.Events.PostStart},
+			{preStopEvent, content.Events.PreStop},
+			{postStopEvent, content.Events.PostStop},
+		} {
+			weight := 0
+			for _, id := range binding.commands {
+				var err error
+				if weight, err = bind(id, binding.event, weight, map[string]bool{}); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	for _, command := range content.Commands {
+		if command.Apply != nil && hooks[command.Apply.Component] == nil {
+			hooks[command.Apply.Component] = &applyHook{component: command.Apply.Component, command: command.Id}
+		}
+	}
+	return hooks, nil
+}
+
+func (r *rendering) addObject(name string, object runtime.Object, containers []workspaces.Component, hook *applyHook) error {
+	unstructured, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return fmt.Errorf("failed to convert the '%s' document: %v", name, err)
+	}
+	cleanup(unstructured)
+	doc := document{name: name, object: unstructured, hook: hook}
+	if len(containers) > 0 {
+		doc.containers = map[string]workspaces.Container{}
+		for _, component := range containers {
+			doc.containers[component.Name] = component.Container.Container
+		}
+	}
+	r.documents = append(r.documents, doc)
+	return nil
+}
+
+func (r *rendering) addManifest(name string, component workspaces.K8sLikeComponent, hook *applyHook) {
+	if component.Inlined == "" {
+		r.warnings = append(r.warnings, fmt.Sprintf("component '%s' is ignored, since only inlined manifests can be exported", name))
+		return
+	}
+	r.documents = append(r.documents, document{name: name, manifest: []byte(component.Inlined), hook: hook})
+}
+
+// addWorkload adds the deployment, or the job when the containers are applied by a command,
+// that runs the given containers, as well as the service that exposes their endpoints.
+func (r *rendering) addWorkload(name string, documentName string, containers []workspaces.Component, volumes map[string]workspaces.Volume, projectSource string, hook *applyHook) error {
+	podSpec, err := buildPodSpec(r.options.Name, containers, volumes, projectSource)
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{
+		PartOfLabel: r.options.Name,
+		NameLabel:   name,
+	}
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: labels},
+		Spec:       podSpec,
+	}
+
+	if hook != nil {
+		template.Spec.RestartPolicy = corev1.RestartPolicyNever
+		return r.addObject(documentName, &batchv1.Job{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       batchv1.JobSpec{Template: template},
+		}, containers, hook)
+	}
+
+	err = r.addObject(documentName, &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: template,
+		},
+	}, containers, nil)
+	if err != nil {
+		return err
+	}
+
+	var ports []corev1.ServicePort
+	for _, component := range containers {
+		for _, endpoint := range component.Container.Endpoints {
+			if validation.EndpointExposure(endpoint) == workspaces.NoneEndpointExposure {
+				continue
+			}
+			protocol := corev1.ProtocolTCP
+			if endpoint.Protocol == workspaces.UDPEndpointProtocol {
+				protocol = corev1.ProtocolUDP
+			}
+			ports = append(ports, corev1.ServicePort{
+				Name:       fmt.Sprintf("%d-%s", endpoint.TargetPort, validation.EndpointProtocol(endpoint)),
+				Protocol:   protocol,
+				Port:       int32(endpoint.TargetPort),
+				TargetPort: intstr.FromInt(endpoint.TargetPort),
+			})
+		}
+	}
+	if len(ports) > 0 {
+		return r.addObject(documentName[:len(documentName)-len("deployment")]+"service", &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeClusterIP,
+				Selector: labels,
+				Ports:    ports,
+			},
+		}, nil, nil)
+	}
+	return nil
+}
+
+// projectsVolume is the name of the pod volume that contains the project sources
+const projectsVolume = "projects"
+
+func buildPodSpec(application string, components []workspaces.Component, volumes map[string]workspaces.Volume, projectSource string) (corev1.PodSpec, error) {
+	podSpec := corev1.PodSpec{}
+	var errors *multierror.Error
+	mountedVolumes := map[string]bool{}
+	for _, component := range components {
+		container := component.Container.Container
+		requirements, err := resources.ContainerResources(container)
+		if err != nil {
+			errors = multierror.Append(errors, fmt.Errorf("container '%s': %v", component.Name, err))
+			continue
+		}
+		if len(requirements.Limits) == 0 {
+			requirements.Limits = nil
+		}
+		if len(requirements.Requests) == 0 {
+			requirements.Requests = nil
+		}
+		podContainer := corev1.Container{
+			Name:      component.Name,
+			Image:     container.Image,
+			Command:   container.Command,
+			Args:      container.Args,
+			Resources: requirements,
+		}
+		if podContainer.Env, err = environment.KubernetesEnv(container.Env, environment.WorkspaceMetadata{Name: application}); err != nil {
+			errors = multierror.Append(errors, fmt.Errorf("container '%s': %v", component.Name, err))
+		}
+		for _, endpoint := range component.Container.Endpoints {
+			protocol := corev1.ProtocolTCP
+			if endpoint.Protocol == workspaces.UDPEndpointProtocol {
+				protocol = corev1.ProtocolUDP
+			}
+			podContainer.Ports = append(podContainer.Ports, corev1.ContainerPort{ContainerPort: int32(endpoint.TargetPort), Protocol: protocol})
+		}
+		if component.Container.ReadinessProbe != nil {
+			if podContainer.ReadinessProbe, err = probes.KubernetesProbe(component.Container, component.Container.ReadinessProbe); err != nil {
+				errors = multierror.Append(errors, fmt.Errorf("readiness probe of container '%s': %v", component.Name, err))
+			}
+		}
+		if component.Container.LivenessProbe != nil {
+			if podContainer.LivenessProbe, err = probes.KubernetesProbe(component.Container, component.Container.LivenessProbe); err != nil {
+				errors = multierror.Append(errors, fmt.Errorf("liveness probe of container '%s': %v", component.Name, err))
+			}
+		}
+
+		if container.MountSources {
+			sourceMapping := container.SourceMapping
+			if sourceMapping == "" {
+				sourceMapping = validation.DefaultSourceMapping
+			}
+			podContainer.VolumeMounts = append(podContainer.VolumeMounts, corev1.VolumeMount{Name: projectsVolume, MountPath: sourceMapping})
+			podContainer.Env = append(podContainer.Env, corev1.EnvVar{Name: "PROJECTS_ROOT", Value: sourceMapping})
+			if projectSource != "" {
+				podContainer.Env = append(podContainer.Env, corev1.EnvVar{Name: "PROJECT_SOURCE", Value: path.Join(sourceMapping, projectSource)})
+			}
+			if !mountedVolumes[projectsVolume] {
+				mountedVolumes[projectsVolume] = true
+				podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+					Name:         projectsVolume,
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				})
+			}
+		}
+
+		for _, mount := range container.VolumeMounts {
+			if _, exists := volumes[mount.Name]; !exists {
+				errors = multierror.Append(errors, fmt.Errorf("container '%s' mounts volume '%s', which is not a volume component", component.Name, mount.Name))
+				continue
+			}
+			mountPath := mount.Path
+			if mountPath == "" {
+				mountPath = "/" + mount.Name
+			}
+			podContainer.VolumeMounts = append(podContainer.VolumeMounts, corev1.VolumeMount{Name: mount.Name, MountPath: mountPath})
+			if mountedVolumes[mount.Name] {
+				continue
+			}
+			mountedVolumes[mount.Name] = true
+			volume := volumes[mount.Name]
+			podVolume := corev1.Volume{Name: mount.Name}
+			if volume.Ephemeral {
+				podVolume.EmptyDir = &corev1.EmptyDirVolumeSource{}
+				if volume.Size != "" {
+					sizeLimit, err := resource.ParseQuantity(volume.Size)
+					if err != nil {
+						errors = multierror.Append(errors, fmt.Errorf("volume '%s': size '%s' is not a valid resource quantity", mount.Name, volume.Size))
+						continue
+					}
+					podVolume.EmptyDir.SizeLimit = &sizeLimit
+				}
+			} else {
+				podVolume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName(application, mount.Name, volume)}
+			}
+			podSpec.Volumes = append(podSpec.Volumes, podVolume)
+		}
+		podSpec.Containers = append(podSpec.Containers, podContainer)
+	}
+	return podSpec, errors.ErrorOrNil()
+}
+
+// claimName returns the name of the persistent volume claim of a volume component:
+// volumes shared with the namespace are named after the volume, so that all the applications
+// that declare the same volume use the same claim.
+func claimName(application string, name string, volume workspaces.Volume) string {
+	if volume.Sharing == workspaces.NamespaceVolumeSharing {
+		return name
+	}
+	return application + "-" + name
+}
+
+func persistentVolumeClaim(name string, application string, volume workspaces.Volume) (*corev1.PersistentVolumeClaim, error) {
+	size := volume.Size
+	if size == "" {
+		size = DefaultVolumeSize
+	}
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return nil, fmt.Errorf("size '%s' is not a valid resource quantity", size)
+	}
+	accessMode := corev1.ReadWriteOnce
+	if volume.AccessMode != "" {
+		accessMode = corev1.PersistentVolumeAccessMode(volume.AccessMode)
+	}
+	var storageClass *string
+	if volume.StorageClass != "" {
+		storageClass = &volume.StorageClass
+	}
+	return &corev1.PersistentVolumeClaim{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{PartOfLabel: application}},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{accessMode},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: quantity},
+			},
+			StorageClassName: storageClass,
+		},
+	}, nil
+}
+
+// cleanup removes the fields that the conversion of typed objects sets without value
+func cleanup(object map[string]interface{}) {
+	delete(object, "status")
+	for key, value := range object {
+		switch typed := value.(type) {
+		case nil:
+			delete(object, key)
+		case map[string]interface{}:
+			cleanup(typed)
+			if (key == "resources" || key == "strategy") && len(typed) == 0 {
+				delete(object, key)
+			}
+		case []interface{}:
+			for _, item := range typed {
+				if itemMap, isMap := item.(map[string]interface{}); isMap {
+					cleanup(itemMap)
+				}
+			}
+		}
+	}
+}
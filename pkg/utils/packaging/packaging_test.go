@@ -0,0 +1,169 @@
+package packaging
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func readDevfile(t *testing.T) *workspaces.DevWorkspaceTemplateSpecContent {
+	bytes, err := ioutil.ReadFile(filepath.Join("test-fixtures", "devfile.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := &workspaces.DevWorkspaceTemplateSpecContent{}
+	if err := yaml.Unmarshal(bytes, content); err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+// assertFiles checks that the files are the same as the ones of the given fixture directory
+func assertFiles(t *testing.T, directory string, files Files) {
+	expected := Files{}
+	err := filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(directory, path)
+		expected[filepath.ToSlash(relative)] = content
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Equal(t, expected.Paths(), files.Paths()) {
+		return
+	}
+	for _, path := range files.Paths() {
+		assert.Equal(t, string(expected[path]), string(files[path]), path)
+	}
+}
+
+func TestHelmChart(t *testing.T) {
+	files, warnings, err := HelmChart(readDevfile(t), Options{Name: "web-app"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assertFiles(t, filepath.Join("test-fixtures", "helm"), files)
+	assert.Equal(t, []string{
+		"image component 'migrate-image' is not built by the export, so image 'quay.io/example/migrate:latest' should be built and pushed before the deployment",
+		"component 'dashboard' is ignored, since only inlined manifests can be exported",
+		"component 'tooling' is ignored, since custom components of class 'che-theia' cannot be exported",
+	}, warnings)
+}
+
+func TestHelmTemplateDelimitersAreEscaped(t *testing.T) {
+	files, _, err := HelmChart(readDevfile(t), Options{Name: "web-app"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	// Helm templates are Go templates
+	tmpl, err := template.New("config").Parse(string(files["templates/config.yaml"]))
+	if !assert.NoError(t, err) {
+		return
+	}
+	var rendered bytes.Buffer
+	if assert.NoError(t, tmpl.Execute(&rendered, nil)) {
+		assert.Contains(t, rendered.String(), `LOG_FORMAT: "{{.Time}} {{.Level}}: {{.Message}}"`)
+	}
+}
+
+func TestKustomizeBase(t *testing.T) {
+	files, warnings, err := KustomizeBase(readDevfile(t), Options{Name: "web-app"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assertFiles(t, filepath.Join("test-fixtures", "kustomize"), files)
+	assert.Equal(t, []string{
+		"image component 'migrate-image' is not built by the export, so image 'quay.io/example/migrate:latest' should be built and pushed before the deployment",
+		"component 'dashboard' is ignored, since only inlined manifests can be exported",
+		"component 'tooling' is ignored, since custom components of class 'che-theia' cannot be exported",
+		"component 'migrate' is applied with the other resources, instead of on the 'preStart' event by command 'migrate', since kustomize has no lifecycle hooks",
+		"component 'secrets' is applied with the other resources, instead of on the 'preStart' event by command 'create-secrets', since kustomize has no lifecycle hooks",
+	}, warnings)
+}
+
+func TestWrite(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "packaging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files, _, err := HelmChart(readDevfile(t), Options{Name: "web-app"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, files.Write(tmpDir)) {
+		return
+	}
+	assertFiles(t, tmpDir, files)
+}
+
+func TestNameIsRequired(t *testing.T) {
+	_, _, err := HelmChart(readDevfile(t), Options{})
+	if assert.Error(t, err) {
+		assert.Equal(t, "a name is required to export a devfile", strings.TrimSpace(err.Error()))
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		component     string
+		update        func(component *workspaces.Component)
+		expectedError string
+	}{
+		{
+			name:      "invalid memory limit",
+			component: "redis",
+			update: func(component *workspaces.Component) {
+				component.Container.MemoryLimit = "lots"
+			},
+			expectedError: "memoryLimit 'lots' is not a valid resource quantity",
+		},
+		{
+			name:      "invalid volume size",
+			component: "cache",
+			update: func(component *workspaces.Component) {
+				component.Volume.Size = "lots"
+			},
+			expectedError: "volume 'cache': size 'lots' is not a valid resource quantity",
+		},
+		{
+			name:      "malformed manifest of a hook",
+			component: "secrets",
+			update: func(component *workspaces.Component) {
+				component.Kubernetes.Inlined = "kind: [Secret"
+			},
+			expectedError: "failed to generate the template of 'secrets': ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := readDevfile(t)
+			for i := range content.Components {
+				if content.Components[i].Name == tt.component {
+					tt.update(&content.Components[i])
+				}
+			}
+			_, _, err := HelmChart(content, Options{Name: "web-app"})
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.expectedError)
+			}
+		})
+	}
+}
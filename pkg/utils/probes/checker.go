@@ -0,0 +1,183 @@
+package probes
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/conditions"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultInterval is the interval between two runs of the failing readiness probes,
+// when the checker has no interval.
+const DefaultInterval = time.Second
+
+// Reasons of the `ComponentsReady` condition set by the checker
+const (
+	ProbesSucceededReason = "ProbesSucceeded"
+	ProbesFailingReason   = "ProbesFailing"
+)
+
+// Prober runs a probe of a container component of a running workspace.
+type Prober interface {
+	// Probe returns an error if the probe fails
+	Probe(ctx context.Context, component string, probe *corev1.Probe) error
+}
+
+// ProberFunc allows using an ordinary function as a `Prober`.
+type ProberFunc func(ctx context.Context, component string, probe *corev1.Probe) error
+
+// Probe calls f(ctx, component, probe).
+func (f ProberFunc) Probe(ctx context.Context, component string, probe *corev1.Probe) error {
+	return f(ctx, component, probe)
+}
+
+// Checker waits for the readiness probes of the container components of a running workspace,
+// so that the `ComponentsReady` condition is only reported once the components are able to serve.
+type Checker struct {
+	// Prober that runs the readiness probes
+	Prober Prober
+
+	// Interval between two runs of the failing readiness probes.
+	//
+	// Defaults to `DefaultInterval`
+	Interval time.Duration
+}
+
+// WaitForComponentsReady runs the readiness probes of the container components of a flattened workspace template
+// until all of them succeed, or until the context is done.
+//
+// The `ComponentsReady` condition of the workspace status is then set to `True`,
+// or to `False` with the components whose probes are still failing, in which case an error is returned.
+// Components without readiness probe are considered ready.
+func (c *Checker) WaitForComponentsReady(ctx context.Context, content *workspaces.DevWorkspaceTemplateSpecContent, status *workspaces.DevWorkspaceStatus) error {
+	type pendingProbe struct {
+		component string
+		probe     *corev1.Probe
+		lastError error
+	}
+	var pending []*pendingProbe
+	for _, component := range content.Components {
+		if component.Container == nil || component.Container.ReadinessProbe == nil {
+			continue
+		}
+		probe, err := KubernetesProbe(component.Container, component.Container.ReadinessProbe)
+		if err != nil {
+			return fmt.Errorf("invalid readiness probe in container '%s': %v", component.Name, err)
+		}
+		pending = append(pending, &pendingProbe{component: component.Name, probe: probe})
+	}
+
+	interval := c.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+	for {
+		var failing []*pendingProbe
+		for _, p := range pending {
+			if p.lastError = c.runProbe(ctx, p.component, p.probe); p.lastError != nil {
+				failing = append(failing, p)
+			}
+		}
+		pending = failing
+		if len(pending) == 0 {
+			conditions.SetCondition(status, workspaces.WorkspaceCondition{
+				Type:   workspaces.WorkspaceComponentsReady,
+				Status: corev1.ConditionTrue,
+				Reason: ProbesSucceededReason,
+			})
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			var messages []string
+			for _, p := range pending {
+				messages = append(messages, fmt.Sprintf("readiness probe of component '%s' is failing: %v", p.component, p.lastError))
+			}
+			conditions.SetCondition(status, workspaces.WorkspaceCondition{
+				Type:    workspaces.WorkspaceComponentsReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  ProbesFailingReason,
+				Message: strings.Join(messages, "\n"),
+			})
+			return fmt.Errorf("components are not ready: %s", strings.Join(messages, ", "))
+		case <-time.After(interval):
+		}
+	}
+}
+
+// runProbe runs a probe with the timeout of the probe
+func (c *Checker) runProbe(ctx context.Context, component string, probe *corev1.Probe) error {
+	timeout := time.Duration(probe.TimeoutSeconds) * time.Second
+	if timeout == 0 {
+		timeout = time.Second
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return c.Prober.Probe(probeCtx, component, probe)
+}
+
+// NetworkProber is a `Prober` that runs HTTP and TCP probes over the network,
+// for workspaces whose containers are reachable from the prober, such as local workspaces.
+// Exec probes are not supported.
+type NetworkProber struct {
+	// Host returns the host of a container component.
+	// When nil, `localhost` is used for all the components.
+	Host func(component string) string
+}
+
+// Probe runs an HTTP or TCP probe against the host of the component.
+// HTTP probes succeed when the response status is a 2xx or 3xx status.
+func (n *NetworkProber) Probe(ctx context.Context, component string, probe *corev1.Probe) error {
+	host := "localhost"
+	if n.Host != nil {
+		host = n.Host(component)
+	}
+	switch {
+	case probe.HTTPGet != nil:
+		probeURL := url.URL{
+			Scheme: strings.ToLower(string(probe.HTTPGet.Scheme)),
+			Host:   net.JoinHostPort(host, probe.HTTPGet.Port.String()),
+			Path:   probe.HTTPGet.Path,
+		}
+		if probeURL.Scheme == "" {
+			probeURL.Scheme = "http"
+		}
+		request, err := http.NewRequest(http.MethodGet, probeURL.String(), nil)
+		if err != nil {
+			return err
+		}
+		// As the kubelet, don't verify the certificates of the containers
+		client := &http.Client{
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, DisableKeepAlives: true},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+		response, err := client.Do(request.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		response.Body.Close()
+		if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("'%s' returned the %d status", probeURL.String(), response.StatusCode)
+		}
+		return nil
+	case probe.TCPSocket != nil:
+		var dialer net.Dialer
+		connection, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, probe.TCPSocket.Port.String()))
+		if err != nil {
+			return err
+		}
+		return connection.Close()
+	}
+	return fmt.Errorf("only HTTP and TCP probes can be run over the network")
+}
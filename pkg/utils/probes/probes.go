@@ -0,0 +1,75 @@
+// Package probes converts the readiness and liveness probes of devfile containers to Kubernetes probes,
+// and checks the readiness probes of running workspaces before reporting their components as ready.
+package probes
+
+import (
+	"fmt"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// KubernetesProbe converts a probe of a container component to a Kubernetes probe.
+//
+// HTTP and TCP probes target the port of the referenced endpoint of the container.
+// HTTP probes use the `HTTPS` scheme when the endpoint is secure, and default to the path of the endpoint.
+func KubernetesProbe(container *workspaces.ContainerComponent, probe *workspaces.Probe) (*corev1.Probe, error) {
+	kubernetesProbe := &corev1.Probe{
+		InitialDelaySeconds: probe.InitialDelaySeconds,
+		PeriodSeconds:       probe.PeriodSeconds,
+		TimeoutSeconds:      probe.TimeoutSeconds,
+		FailureThreshold:    probe.FailureThreshold,
+	}
+	switch {
+	case probe.Http != nil:
+		endpoint, err := probeEndpoint(container, probe.Http.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		path := probe.Http.Path
+		if path == "" {
+			path = endpoint.Path
+		}
+		if path == "" {
+			path = "/"
+		}
+		scheme := corev1.URISchemeHTTP
+		if isSecure(endpoint) {
+			scheme = corev1.URISchemeHTTPS
+		}
+		kubernetesProbe.HTTPGet = &corev1.HTTPGetAction{
+			Path:   path,
+			Port:   intstr.FromInt(endpoint.TargetPort),
+			Scheme: scheme,
+		}
+	case probe.Tcp != nil:
+		endpoint, err := probeEndpoint(container, probe.Tcp.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		kubernetesProbe.TCPSocket = &corev1.TCPSocketAction{Port: intstr.FromInt(endpoint.TargetPort)}
+	case probe.Exec != nil:
+		kubernetesProbe.Exec = &corev1.ExecAction{Command: probe.Exec.Command}
+	default:
+		return nil, fmt.Errorf("the probe should be an HTTP, TCP or exec probe")
+	}
+	return kubernetesProbe, nil
+}
+
+func probeEndpoint(container *workspaces.ContainerComponent, name string) (*workspaces.Endpoint, error) {
+	for i := range container.Endpoints {
+		if container.Endpoints[i].Name == name {
+			return &container.Endpoints[i], nil
+		}
+	}
+	return nil, fmt.Errorf("the probe references endpoint '%s', which doesn't exist in the container", name)
+}
+
+func isSecure(endpoint *workspaces.Endpoint) bool {
+	switch endpoint.Protocol {
+	case workspaces.HTTPSEndpointProtocol, workspaces.WSSEndpointProtocol:
+		return true
+	}
+	return endpoint.Secure
+}
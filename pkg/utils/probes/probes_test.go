@@ -0,0 +1,215 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/conditions"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+const template = `
+components:
+  - name: web
+    container:
+      image: quay.io/example/web:latest
+      endpoints:
+        - name: http
+          targetPort: 8080
+          path: /app
+        - name: admin
+          targetPort: 9443
+          protocol: https
+      readinessProbe:
+        http:
+          endpoint: http
+        periodSeconds: 5
+        failureThreshold: 6
+      livenessProbe:
+        http:
+          endpoint: admin
+          path: /healthz
+  - name: db
+    container:
+      image: quay.io/example/db:latest
+      endpoints:
+        - name: db
+          targetPort: 5432
+          protocol: tcp
+      readinessProbe:
+        tcp:
+          endpoint: db
+      livenessProbe:
+        exec:
+          command: ["pg_isready"]
+  - name: tools
+    container:
+      image: quay.io/example/tools:latest
+`
+
+func readTemplate(t *testing.T) *workspaces.DevWorkspaceTemplateSpecContent {
+	content := &workspaces.DevWorkspaceTemplateSpecContent{}
+	if err := yaml.Unmarshal([]byte(template), content); err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestKubernetesProbe(t *testing.T) {
+	content := readTemplate(t)
+	web, db := content.Components[0].Container, content.Components[1].Container
+	tests := []struct {
+		name      string
+		container *workspaces.ContainerComponent
+		probe     *workspaces.Probe
+		expected  *corev1.Probe
+	}{
+		{
+			name:      "HTTP probe with the endpoint path",
+			container: web,
+			probe:     web.ReadinessProbe,
+			expected: &corev1.Probe{
+				Handler: corev1.Handler{
+					HTTPGet: &corev1.HTTPGetAction{Path: "/app", Port: intstr.FromInt(8080), Scheme: corev1.URISchemeHTTP},
+				},
+				PeriodSeconds:    5,
+				FailureThreshold: 6,
+			},
+		},
+		{
+			name:      "HTTP probe on a secure endpoint",
+			container: web,
+			probe:     web.LivenessProbe,
+			expected: &corev1.Probe{
+				Handler: corev1.Handler{
+					HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(9443), Scheme: corev1.URISchemeHTTPS},
+				},
+			},
+		},
+		{
+			name:      "TCP probe",
+			container: db,
+			probe:     db.ReadinessProbe,
+			expected: &corev1.Probe{
+				Handler: corev1.Handler{
+					TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(5432)},
+				},
+			},
+		},
+		{
+			name:      "Exec probe",
+			container: db,
+			probe:     db.LivenessProbe,
+			expected: &corev1.Probe{
+				Handler: corev1.Handler{
+					Exec: &corev1.ExecAction{Command: []string{"pg_isready"}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe, err := KubernetesProbe(tt.container, tt.probe)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, probe)
+			}
+		})
+	}
+
+	_, err := KubernetesProbe(db, &workspaces.Probe{ProbeHandler: workspaces.ProbeHandler{Tcp: &workspaces.TcpProbe{Endpoint: "admin"}}})
+	if assert.Error(t, err) {
+		assert.Equal(t, "the probe references endpoint 'admin', which doesn't exist in the container", err.Error())
+	}
+}
+
+func TestWaitForComponentsReady(t *testing.T) {
+	runs := map[string]int{}
+	prober := ProberFunc(func(ctx context.Context, component string, probe *corev1.Probe) error {
+		runs[component]++
+		if component == "db" && runs[component] < 3 {
+			return fmt.Errorf("connection refused")
+		}
+		return nil
+	})
+	checker := &Checker{Prober: prober, Interval: time.Millisecond}
+	status := &workspaces.DevWorkspaceStatus{}
+
+	err := checker.WaitForComponentsReady(context.Background(), readTemplate(t), status)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, map[string]int{"web": 1, "db": 3}, runs)
+	condition := conditions.GetCondition(status, workspaces.WorkspaceComponentsReady)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t, ProbesSucceededReason, condition.Reason)
+	}
+}
+
+func TestWaitForComponentsReadyTimeout(t *testing.T) {
+	prober := ProberFunc(func(ctx context.Context, component string, probe *corev1.Probe) error {
+		if component == "db" {
+			return fmt.Errorf("connection refused")
+		}
+		return nil
+	})
+	checker := &Checker{Prober: prober, Interval: time.Millisecond}
+	status := &workspaces.DevWorkspaceStatus{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := checker.WaitForComponentsReady(ctx, readTemplate(t), status)
+	if assert.Error(t, err) {
+		assert.Equal(t, "components are not ready: readiness probe of component 'db' is failing: connection refused", err.Error())
+	}
+	condition := conditions.GetCondition(status, workspaces.WorkspaceComponentsReady)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+		assert.Equal(t, ProbesFailingReason, condition.Reason)
+		assert.Equal(t, "readiness probe of component 'db' is failing: connection refused", condition.Message)
+	}
+}
+
+func TestNetworkProber(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ready" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, port, err := net.SplitHostPort(serverURL.Host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prober := &NetworkProber{Host: func(string) string { return host }}
+
+	httpProbe := func(path string) *corev1.Probe {
+		return &corev1.Probe{Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: path, Port: intstr.FromString(port)}}}
+	}
+	assert.NoError(t, prober.Probe(context.Background(), "web", httpProbe("/ready")))
+	err = prober.Probe(context.Background(), "web", httpProbe("/starting"))
+	if assert.Error(t, err) {
+		assert.True(t, strings.HasSuffix(err.Error(), "/starting' returned the 503 status"), err.Error())
+	}
+
+	tcpProbe := &corev1.Probe{Handler: corev1.Handler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString(port)}}}
+	assert.NoError(t, prober.Probe(context.Background(), "web", tcpProbe))
+
+	execProbe := &corev1.Probe{Handler: corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"true"}}}}
+	assert.Error(t, prober.Probe(context.Background(), "web", execProbe))
+}
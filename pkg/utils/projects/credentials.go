@@ -0,0 +1,133 @@
+package projects
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/credentials"
+)
+
+// remoteAccess contains what is needed to run git commands that access a remote
+type remoteAccess struct {
+	// environment variables that configure the authentication to the remote
+	env []string
+	// credentials of the remote, used to redact errors
+	credentials *credentials.Credentials
+	// temporary directory that contains the SSH key and known hosts files
+	tmpDir string
+}
+
+func (a *remoteAccess) cleanup() {
+	if a.tmpDir != "" {
+		os.RemoveAll(a.tmpDir)
+	}
+}
+
+// isSSHRemote returns true for `ssh://` urls and scp-like `[user@]host:path` remotes
+func isSSHRemote(remote string) bool {
+	if parsed, err := url.Parse(remote); err == nil && parsed.Scheme != "" {
+		return parsed.Scheme == "ssh" || parsed.Scheme == "git+ssh"
+	}
+	colon := strings.Index(remote, ":")
+	return colon > 0 && !strings.Contains(remote[:colon], "/")
+}
+
+func isHTTPRemote(remote string) bool {
+	parsed, err := url.Parse(remote)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https")
+}
+
+// shellQuote quotes a value for the shell that git uses to run `GIT_SSH_COMMAND`
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// remoteAccessFor returns the git configuration that authenticates to the given remote
+// with the credentials referenced in the project source, if any:
+//
+// - for HTTP remotes, a password or a token is sent with basic authentication,
+//
+// - for SSH remotes, the private key, and the known hosts if provided, are written to temporary files
+// that are used by the SSH command.
+//
+// Git is never allowed to prompt for credentials.
+func (f *Fetcher) remoteAccessFor(source workspaces.GitLikeProjectSource, remoteName string) (*remoteAccess, error) {
+	access := &remoteAccess{
+		env: []string{"GIT_TERMINAL_PROMPT=0"},
+	}
+	reference, hasCredentials := source.Credentials[remoteName]
+	if !hasCredentials {
+		return access, nil
+	}
+	if f.Credentials == nil {
+		return nil, fmt.Errorf("remote '%s' references credentials, but no credentials provider is configured", remoteName)
+	}
+	creds, err := f.Credentials.Credentials(reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the credentials of remote '%s': %v", remoteName, err)
+	}
+	access.credentials = creds
+	remote := source.Remotes[remoteName]
+
+	switch {
+	case isHTTPRemote(remote):
+		secret := creds.Token
+		if secret == "" {
+			secret = creds.Password
+		}
+		if secret == "" {
+			return nil, fmt.Errorf("credentials of remote '%s' should provide a token or a password, since it is an HTTP remote", remoteName)
+		}
+		if creds.Username == "" {
+			// The default username is kept in the credentials used to redact errors,
+			// so that the encoded authorization header is redacted as well
+			withUsername := *creds
+			withUsername.Username = "git"
+			access.credentials = &withUsername
+		}
+		// The authorization header is passed through the environment, rather than the command line,
+		// so that it is not visible in the list of processes.
+		header := "Authorization: Basic " + credentials.BasicAuth(access.credentials.Username, secret)
+		access.env = append(access.env,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.extraHeader",
+			"GIT_CONFIG_VALUE_0="+header)
+	case isSSHRemote(remote):
+		if len(creds.SSHPrivateKey) == 0 {
+			return nil, fmt.Errorf("credentials of remote '%s' should provide an SSH private key, since it is an SSH remote", remoteName)
+		}
+		tmpDir, err := ioutil.TempDir("", "git-ssh-")
+		if err != nil {
+			return nil, err
+		}
+		access.tmpDir = tmpDir
+		keyFile := filepath.Join(tmpDir, "id")
+		if err := ioutil.WriteFile(keyFile, creds.SSHPrivateKey, 0600); err != nil {
+			access.cleanup()
+			return nil, err
+		}
+		knownHostsFile := filepath.Join(tmpDir, "known_hosts")
+		strictHostKeyChecking := "accept-new"
+		if len(creds.KnownHosts) > 0 {
+			if err := ioutil.WriteFile(knownHostsFile, creds.KnownHosts, 0600); err != nil {
+				access.cleanup()
+				return nil, err
+			}
+			strictHostKeyChecking = "yes"
+		}
+		sshCommand := f.SSHCommand
+		if sshCommand == "" {
+			sshCommand = "ssh"
+		}
+		access.env = append(access.env, fmt.Sprintf("GIT_SSH_COMMAND=%s -i %s -o IdentitiesOnly=yes -o UserKnownHostsFile=%s -o StrictHostKeyChecking=%s",
+			sshCommand, shellQuote(keyFile), shellQuote(knownHostsFile), strictHostKeyChecking))
+	default:
+		return nil, fmt.Errorf("credentials cannot be used with remote '%s', which is neither an HTTP nor an SSH remote", remoteName)
+	}
+	return access, nil
+}
@@ -0,0 +1,187 @@
+package projects
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+const testToken = "ghp_0123456789secret"
+
+func staticCredentials(secretName string, creds *credentials.Credentials) credentials.Provider {
+	return credentials.ProviderFunc(func(reference workspaces.CredentialsReference) (*credentials.Credentials, error) {
+		if reference.SecretName != secretName {
+			return nil, errors.New("secret not found")
+		}
+		return creds, nil
+	})
+}
+
+// newGitHTTPServer serves the git repositories found in `root` through `git http-backend`,
+// and only accepts requests authenticated with basic authentication and the `testToken` password.
+func newGitHTTPServer(t *testing.T, root string) *httptest.Server {
+	execPath, err := exec.Command("git", "--exec-path").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &cgi.Handler{
+		Path: filepath.Join(strings.TrimSpace(string(execPath)), "git-http-backend"),
+		Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, password, ok := r.BasicAuth(); !ok || password != testToken {
+			w.Header().Set("WWW-Authenticate", `Basic realm="git"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		backend.ServeHTTP(w, r)
+	}))
+}
+
+func TestFetchPrivateHTTPRemote(t *testing.T) {
+	if _, err := exec.Command("git", "--exec-path").Output(); err != nil {
+		t.Skip("git is not available")
+	}
+	tmpDir, err := ioutil.TempDir("", "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	createBareRepository(t, tmpDir, "private")
+	server := newGitHTTPServer(t, tmpDir)
+	defer server.Close()
+	remote := server.URL + "/private.git"
+
+	anonymous := gitProject("anonymous", map[string]string{"origin": remote}, nil)
+	_, err = NewFetcher().FetchProject(anonymous, tmpDir)
+	assert.Error(t, err, "fetching a private repository without credentials should fail")
+
+	project := gitProject("private", map[string]string{"origin": remote}, nil)
+	project.Git.Credentials = map[string]workspaces.CredentialsReference{
+		"origin": {SecretName: "git-token"},
+	}
+	_, err = NewFetcher().FetchProject(project, tmpDir)
+	assert.EqualError(t, err, "failed to fetch project 'private': remote 'origin' references credentials, but no credentials provider is configured")
+
+	fetcher := NewFetcher()
+	fetcher.Credentials = staticCredentials("git-token", &credentials.Credentials{Token: testToken})
+	fetched, err := fetcher.FetchProject(project, filepath.Join(tmpDir, "projects"))
+	if assert.NoError(t, err) {
+		assertFiles(t, fetched.Path, "README.md", "CHANGELOG.md", "backend/main.go")
+		gitConfig, _ := ioutil.ReadFile(filepath.Join(fetched.Path, ".git", "config"))
+		assert.NotContains(t, string(gitConfig), "Authorization", "credentials should not be persisted in the repository")
+	}
+}
+
+func TestFetchErrorsAreRedacted(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	project := gitProject("private", map[string]string{"origin": "https://developer:" + testToken + "@127.0.0.1:1/private.git"}, nil)
+	_, err = NewFetcher().FetchProject(project, tmpDir)
+	if assert.Error(t, err) {
+		assert.NotContains(t, err.Error(), testToken)
+	}
+
+	project = gitProject("ssh-with-token", map[string]string{"origin": "git@example.com:private.git"}, nil)
+	project.Git.Credentials = map[string]workspaces.CredentialsReference{
+		"origin": {SecretName: "git-token"},
+	}
+	fetcher := NewFetcher()
+	fetcher.Credentials = staticCredentials("git-token", &credentials.Credentials{Token: testToken})
+	_, err = fetcher.FetchProject(project, tmpDir)
+	assert.EqualError(t, err, "failed to fetch project 'ssh-with-token': credentials of remote 'origin' should provide an SSH private key, since it is an SSH remote")
+
+	project.Git.Credentials = map[string]workspaces.CredentialsReference{
+		"upstream": {SecretName: "git-token"},
+	}
+	_, err = fetcher.FetchProject(project, tmpDir)
+	assert.EqualError(t, err, "failed to fetch project 'ssh-with-token': credentials are defined for remote 'upstream', which is not one of the configured remotes")
+}
+
+func TestAuthorizationHeaderIsRedacted(t *testing.T) {
+	project := gitProject("private", map[string]string{"origin": "https://example.com/private.git"}, nil)
+	project.Git.Credentials = map[string]workspaces.CredentialsReference{
+		"origin": {SecretName: "git-token"},
+	}
+	fetcher := NewFetcher()
+	fetcher.Credentials = staticCredentials("git-token", &credentials.Credentials{Token: testToken})
+	access, err := fetcher.remoteAccessFor(project.Git.GitLikeProjectSource, "origin")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer access.cleanup()
+
+	header := access.env[len(access.env)-1]
+	assert.Equal(t, "GIT_CONFIG_VALUE_0=Authorization: Basic "+credentials.BasicAuth("git", testToken), header)
+	assert.Equal(t, "GIT_CONFIG_VALUE_0=Authorization: Basic *****", access.credentials.Redact(header))
+}
+
+// fakeSSH is an SSH stand-in that checks the private key passed with `-i`,
+// and runs the requested git command locally.
+const fakeSSH = `#!/bin/sh
+key=""
+while [ $# -gt 1 ]; do
+  case "$1" in
+    -i) key="$2"; shift 2 ;;
+    -G) exit 1 ;;
+    *) shift ;;
+  esac
+done
+if [ "$(cat "$key" 2>/dev/null)" != "test-private-key" ]; then
+  echo "Permission denied (publickey)." >&2
+  exit 255
+fi
+exec sh -c "$1"
+`
+
+func TestFetchPrivateSSHRemote(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	repository, _ := createBareRepository(t, tmpDir, "private")
+	sshCommand := filepath.Join(tmpDir, "fake-ssh")
+	if err := ioutil.WriteFile(sshCommand, []byte(fakeSSH), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	project := gitProject("private", map[string]string{"origin": "ssh://git@localhost" + repository}, nil)
+	project.Git.Credentials = map[string]workspaces.CredentialsReference{
+		"origin": {SecretName: "ssh-key"},
+	}
+
+	fetcher := NewFetcher()
+	fetcher.SSHCommand = sshCommand
+	fetcher.Credentials = staticCredentials("ssh-key", &credentials.Credentials{SSHPrivateKey: []byte("wrong-private-key")})
+	_, err = fetcher.FetchProject(project, filepath.Join(tmpDir, "denied"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Permission denied (publickey).")
+		assert.NotContains(t, err.Error(), "wrong-private-key")
+	}
+
+	fetcher.Credentials = staticCredentials("ssh-key", &credentials.Credentials{SSHPrivateKey: []byte("test-private-key")})
+	fetched, err := fetcher.FetchProject(project, filepath.Join(tmpDir, "projects"))
+	if assert.NoError(t, err) {
+		assertFiles(t, fetched.Path, "README.md", "CHANGELOG.md", "backend/main.go")
+		assert.Equal(t, "main", fetched.Revision)
+	}
+
+	leftovers, _ := filepath.Glob(filepath.Join(os.TempDir(), "git-ssh-*", "id"))
+	assert.Empty(t, leftovers, "temporary SSH keys should be removed")
+}
@@ -0,0 +1,177 @@
+package projects
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+)
+
+// checkoutRemote returns the name of the remote to check out from:
+// the `checkoutFrom` remote if specified, or the only remote otherwise.
+func checkoutRemote(source workspaces.GitLikeProjectSource) (string, error) {
+	if len(source.Remotes) == 0 {
+		return "", fmt.Errorf("at least one remote should be configured")
+	}
+	if source.CheckoutFrom != nil && source.CheckoutFrom.Remote != "" {
+		if _, exists := source.Remotes[source.CheckoutFrom.Remote]; !exists {
+			return "", fmt.Errorf("checkout remote '%s' is not one of the configured remotes", source.CheckoutFrom.Remote)
+		}
+		return source.CheckoutFrom.Remote, nil
+	}
+	if len(source.Remotes) > 1 {
+		return "", fmt.Errorf("a checkout remote should be specified, since several remotes are configured")
+	}
+	for name := range source.Remotes {
+		return name, nil
+	}
+	return "", nil
+}
+
+// sparseCheckoutPattern returns the sparse-checkout pattern that selects the given sub-directory
+func sparseCheckoutPattern(dir string) string {
+	return "/" + strings.Trim(path.Clean(filepath.ToSlash(dir)), "/") + "/"
+}
+
+func (f *Fetcher) git(dir string, args ...string) (string, error) {
+	return f.remoteGit(&remoteAccess{}, dir, args...)
+}
+
+// remoteGit runs a git command that accesses a remote with the given access configuration.
+// Secrets are redacted from the returned errors.
+func (f *Fetcher) remoteGit(access *remoteAccess, dir string, args ...string) (string, error) {
+	gitCommand := f.GitCommand
+	if gitCommand == "" {
+		gitCommand = "git"
+	}
+	cmd := exec.Command(gitCommand, append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(), access.env...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", access.credentials.RedactError(fmt.Errorf("git %s failed: %v: %s", args[0], err, strings.TrimSpace(stderr.String())))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// refExists returns true if the given reference resolves to a commit in the repository
+func (f *Fetcher) refExists(dir, ref string) bool {
+	_, err := f.git(dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	return err == nil
+}
+
+// cloneGitProject initializes a repository in `target`, adds all the remotes, fetches them,
+// and checks out the requested revision of the checkout remote.
+//
+// The revision is looked up as a branch of the checkout remote, then as a tag, then as a commit.
+// As specified in the `CheckoutFrom` documentation, the default branch of the checkout remote
+// is used if the revision is missing or not found.
+func (f *Fetcher) cloneGitProject(projectName string, source workspaces.GitLikeProjectSource, target string, fetched *FetchedProject) error {
+	remote, err := checkoutRemote(source)
+	if err != nil {
+		return err
+	}
+	for name := range source.Credentials {
+		if _, exists := source.Remotes[name]; !exists {
+			return fmt.Errorf("credentials are defined for remote '%s', which is not one of the configured remotes", name)
+		}
+	}
+
+	if _, err := f.git(target, "init", "--quiet"); err != nil {
+		return err
+	}
+	remoteNames := []string{}
+	for name := range source.Remotes {
+		remoteNames = append(remoteNames, name)
+	}
+	sort.Strings(remoteNames)
+	for _, name := range remoteNames {
+		if _, err := f.git(target, "remote", "add", name, source.Remotes[name]); err != nil {
+			return err
+		}
+	}
+
+	if source.SparseCheckoutDir != "" {
+		if _, err := f.git(target, "config", "core.sparseCheckout", "true"); err != nil {
+			return err
+		}
+		sparseCheckoutFile := filepath.Join(target, ".git", "info", "sparse-checkout")
+		if err := ioutil.WriteFile(sparseCheckoutFile, []byte(sparseCheckoutPattern(source.SparseCheckoutDir)+"\n"), 0644); err != nil {
+			return err
+		}
+	}
+
+	accesses := map[string]*remoteAccess{}
+	defer func() {
+		for _, access := range accesses {
+			access.cleanup()
+		}
+	}()
+	for _, name := range remoteNames {
+		access, err := f.remoteAccessFor(source, name)
+		if err != nil {
+			return err
+		}
+		accesses[name] = access
+	}
+
+	// Tags are only fetched from the checkout remote, since tags of other remotes
+	// could have the same names and would conflict.
+	for _, name := range remoteNames {
+		tagsOption := "--no-tags"
+		if name == remote {
+			tagsOption = "--tags"
+		}
+		if _, err := f.remoteGit(accesses[name], target, "fetch", "--quiet", tagsOption, name); err != nil {
+			return err
+		}
+	}
+
+	revision := ""
+	if source.CheckoutFrom != nil {
+		revision = source.CheckoutFrom.Revision
+	}
+
+	switch {
+	case revision != "" && f.refExists(target, "refs/remotes/"+remote+"/"+revision):
+		_, err = f.git(target, "checkout", "--quiet", "-b", revision, "--track", remote+"/"+revision)
+	case revision != "" && f.refExists(target, "refs/tags/"+revision):
+		_, err = f.git(target, "checkout", "--quiet", "refs/tags/"+revision)
+	case revision != "" && f.refExists(target, revision):
+		_, err = f.git(target, "checkout", "--quiet", revision)
+	default:
+		if revision != "" {
+			fetched.Warnings = append(fetched.Warnings, fmt.Sprintf("revision '%s' was not found in remote '%s' of project '%s', the default branch is used instead",
+				revision, remote, projectName))
+		}
+		revision, err = f.checkoutDefaultBranch(accesses[remote], target, remote)
+	}
+	if err != nil {
+		return err
+	}
+	fetched.Revision = revision
+	return nil
+}
+
+func (f *Fetcher) checkoutDefaultBranch(access *remoteAccess, target, remote string) (string, error) {
+	if _, err := f.remoteGit(access, target, "remote", "set-head", remote, "--auto"); err != nil {
+		return "", err
+	}
+	head, err := f.git(target, "symbolic-ref", "--short", "refs/remotes/"+remote+"/HEAD")
+	if err != nil {
+		return "", err
+	}
+	branch := strings.TrimPrefix(head, remote+"/")
+	if _, err := f.git(target, "checkout", "--quiet", "-b", branch, "--track", head); err != nil {
+		return "", err
+	}
+	return branch, nil
+}
@@ -0,0 +1,138 @@
+// Package projects materializes the projects of a devfile on disk,
+// from their Git, GitHub or Zip sources.
+package projects
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/credentials"
+	"github.com/devfile/api/pkg/utils/fetching"
+	"github.com/devfile/api/pkg/utils/validation"
+)
+
+// Fetcher fetches the sources of projects into a local directory.
+type Fetcher struct {
+	// Path of the git executable used to clone Git and GitHub projects.
+	// Defaults to `git`, looked up in the `PATH`.
+	GitCommand string
+
+	// Fetcher used to download the archives of Zip projects.
+	// Defaults to `fetching.DefaultFetcher`.
+	ArchiveFetcher fetching.Fetcher
+
+	// Path of the SSH executable used to access SSH git remotes with credentials.
+	// Defaults to `ssh`, looked up in the `PATH`.
+	SSHCommand string
+
+	// Provider of the credentials referenced by the remotes of Git and GitHub projects.
+	// When nil, projects that reference credentials cannot be fetched.
+	Credentials credentials.Provider
+}
+
+// NewFetcher returns a fetcher that uses the default git executable and archive fetcher
+func NewFetcher() *Fetcher {
+	return &Fetcher{}
+}
+
+// FetchedProject describes a project fetched on disk
+type FetchedProject struct {
+	// Name of the project
+	Name string
+
+	// Absolute path of the directory that contains the project sources
+	Path string
+
+	// For Git-like projects, the branch, tag or commit that was checked out
+	Revision string
+
+	// Non-fatal issues found while fetching the project,
+	// such as a requested revision that was not found, and replaced by the default branch
+	Warnings []string
+}
+
+// FetchProject fetches the sources of the project into its clone directory under `projectsRoot`:
+// its `clonePath` if specified, or its name otherwise.
+// Clone paths that are not inside `projectsRoot` are rejected.
+//
+// The target directory should either not exist or be empty,
+// and is emptied again if the project cannot be fetched.
+// When the project source has a `sparseCheckoutDir`, only this sub-directory of the sources
+// is populated, at the same relative location in the target directory.
+func (f *Fetcher) FetchProject(project workspaces.Project, projectsRoot string) (*FetchedProject, error) {
+	root, err := filepath.Abs(projectsRoot)
+	if err != nil {
+		return nil, err
+	}
+	cloneDirectory, err := validation.CloneDirectory(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch project '%s': %v", project.Name, err)
+	}
+	target := filepath.Join(root, filepath.FromSlash(cloneDirectory))
+	if err := ensureEmptyDirectory(target); err != nil {
+		return nil, fmt.Errorf("failed to fetch project '%s': %v", project.Name, err)
+	}
+
+	fetched := &FetchedProject{
+		Name: project.Name,
+		Path: target,
+	}
+	switch {
+	case project.Git != nil:
+		err = f.cloneGitProject(project.Name, project.Git.GitLikeProjectSource, target, fetched)
+	case project.Github != nil:
+		err = f.cloneGitProject(project.Name, project.Github.GitLikeProjectSource, target, fetched)
+	case project.Zip != nil:
+		err = f.extractZipProject(*project.Zip, target)
+	case project.Custom != nil:
+		err = fmt.Errorf("custom project source class '%s' is not supported", project.Custom.ProjectSourceClass)
+	default:
+		err = fmt.Errorf("no project source is specified")
+	}
+	if err != nil {
+		cleanDirectory(target)
+		return nil, fmt.Errorf("failed to fetch project '%s': %v", project.Name, err)
+	}
+	return fetched, nil
+}
+
+// FetchProjects fetches all the projects of a workspace template into `projectsRoot`,
+// in the order in which they are declared.
+func (f *Fetcher) FetchProjects(projects []workspaces.Project, projectsRoot string) ([]*FetchedProject, error) {
+	fetched := []*FetchedProject{}
+	for _, project := range projects {
+		fetchedProject, err := f.FetchProject(project, projectsRoot)
+		if err != nil {
+			return fetched, err
+		}
+		fetched = append(fetched, fetchedProject)
+	}
+	return fetched, nil
+}
+
+func ensureEmptyDirectory(dir string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return os.MkdirAll(dir, 0755)
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("target directory '%s' is not empty", dir)
+	}
+	return nil
+}
+
+func cleanDirectory(dir string) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		os.RemoveAll(filepath.Join(dir, entry.Name()))
+	}
+}
@@ -0,0 +1,316 @@
+package projects
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// createBareRepository creates a local bare repository with:
+// - a `main` default branch, with a `README.md` file and a `backend/main.go` file,
+// - a `v1.0` tag on the first commit of `main`,
+// - a `feature` branch that adds a `feature.txt` file.
+//
+// It returns the path of the bare repository and the id of the first commit.
+func createBareRepository(t *testing.T, parentDir, name string) (string, string) {
+	workDir := filepath.Join(parentDir, name+"-work")
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, workDir, "init", "--quiet", "-b", "main")
+	writeFiles(t, workDir, map[string]string{
+		"README.md":       "# " + name,
+		"backend/main.go": "package main",
+	})
+	runGit(t, workDir, "add", "-A")
+	runGit(t, workDir, "commit", "--quiet", "-m", "Initial commit")
+	firstCommit := runGit(t, workDir, "rev-parse", "HEAD")
+	runGit(t, workDir, "tag", "v1.0")
+
+	writeFiles(t, workDir, map[string]string{"CHANGELOG.md": "1.1"})
+	runGit(t, workDir, "add", "-A")
+	runGit(t, workDir, "commit", "--quiet", "-m", "Second commit")
+
+	runGit(t, workDir, "checkout", "--quiet", "-b", "feature", firstCommit)
+	writeFiles(t, workDir, map[string]string{"feature.txt": "feature"})
+	runGit(t, workDir, "add", "-A")
+	runGit(t, workDir, "commit", "--quiet", "-m", "Feature commit")
+	runGit(t, workDir, "checkout", "--quiet", "main")
+
+	bareDir := filepath.Join(parentDir, name+".git")
+	runGit(t, parentDir, "clone", "--quiet", "--bare", workDir, bareDir)
+	return bareDir, firstCommit
+}
+
+func gitProject(name string, remotes map[string]string, checkoutFrom *workspaces.CheckoutFrom) workspaces.Project {
+	return workspaces.Project{
+		Name: name,
+		ProjectSource: workspaces.ProjectSource{
+			Git: &workspaces.GitProjectSource{
+				GitLikeProjectSource: workspaces.GitLikeProjectSource{
+					Remotes:      remotes,
+					CheckoutFrom: checkoutFrom,
+				},
+			},
+		},
+	}
+}
+
+func assertFiles(t *testing.T, dir string, expected ...string) {
+	actual := []string{}
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !info.IsDir() {
+			relative, _ := filepath.Rel(dir, path)
+			actual = append(actual, filepath.ToSlash(relative))
+		}
+		return nil
+	})
+	assert.ElementsMatch(t, expected, actual)
+}
+
+func TestCloneDefaultBranch(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	repository, _ := createBareRepository(t, tmpDir, "nodejs-web-app")
+
+	project := gitProject("nodejs-web-app", map[string]string{"origin": repository}, nil)
+	project.ClonePath = "webapp/sources"
+	fetched, err := NewFetcher().FetchProject(project, filepath.Join(tmpDir, "projects"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, filepath.Join(tmpDir, "projects", "webapp", "sources"), fetched.Path)
+	assert.Equal(t, "main", fetched.Revision)
+	assert.Empty(t, fetched.Warnings)
+	assertFiles(t, fetched.Path, "README.md", "CHANGELOG.md", "backend/main.go")
+	assert.Equal(t, "main", runGit(t, fetched.Path, "rev-parse", "--abbrev-ref", "HEAD"))
+}
+
+func TestCheckoutRevision(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	repository, firstCommit := createBareRepository(t, tmpDir, "nodejs-web-app")
+
+	tests := []struct {
+		name          string
+		revision      string
+		expectedFiles []string
+		warning       string
+	}{
+		{"branch", "feature", []string{"README.md", "backend/main.go", "feature.txt"}, ""},
+		{"tag", "v1.0", []string{"README.md", "backend/main.go"}, ""},
+		{"commit", firstCommit, []string{"README.md", "backend/main.go"}, ""},
+		{"unknown", "unknown", []string{"README.md", "CHANGELOG.md", "backend/main.go"},
+			"revision 'unknown' was not found in remote 'origin' of project 'unknown', the default branch is used instead"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			project := gitProject(test.name, map[string]string{"origin": repository}, &workspaces.CheckoutFrom{Revision: test.revision})
+			fetched, err := NewFetcher().FetchProject(project, tmpDir)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assertFiles(t, fetched.Path, test.expectedFiles...)
+			if test.warning != "" {
+				assert.Equal(t, []string{test.warning}, fetched.Warnings)
+				assert.Equal(t, "main", fetched.Revision)
+			} else {
+				assert.Empty(t, fetched.Warnings)
+				assert.Equal(t, test.revision, fetched.Revision)
+			}
+		})
+	}
+}
+
+func TestMultipleRemotes(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	origin, _ := createBareRepository(t, tmpDir, "origin")
+	upstream, _ := createBareRepository(t, tmpDir, "upstream")
+	remotes := map[string]string{"origin": origin, "upstream": upstream}
+
+	_, err = NewFetcher().FetchProject(gitProject("ambiguous", remotes, nil), tmpDir)
+	assert.EqualError(t, err, "failed to fetch project 'ambiguous': a checkout remote should be specified, since several remotes are configured")
+
+	_, err = NewFetcher().FetchProject(gitProject("unknown-remote", remotes, &workspaces.CheckoutFrom{Remote: "fork"}), tmpDir)
+	assert.EqualError(t, err, "failed to fetch project 'unknown-remote': checkout remote 'fork' is not one of the configured remotes")
+
+	fetched, err := NewFetcher().FetchProject(gitProject("project", remotes, &workspaces.CheckoutFrom{Remote: "upstream"}), tmpDir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "origin\nupstream", runGit(t, fetched.Path, "remote"))
+	assert.Equal(t, upstream, runGit(t, fetched.Path, "remote", "get-url", "upstream"))
+	assert.Equal(t, "upstream/main", runGit(t, fetched.Path, "rev-parse", "--abbrev-ref", "main@{upstream}"))
+	assert.Equal(t, "# upstream", readFile(t, filepath.Join(fetched.Path, "README.md")))
+}
+
+func TestGitSparseCheckout(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	repository, _ := createBareRepository(t, tmpDir, "nodejs-web-app")
+
+	project := gitProject("backend", map[string]string{"origin": repository}, nil)
+	project.Git.SparseCheckoutDir = "backend"
+	fetched, err := NewFetcher().FetchProject(project, tmpDir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assertFiles(t, fetched.Path, "backend/main.go")
+}
+
+func readFile(t *testing.T, path string) string {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func createZip(t *testing.T, path string, files map[string]string) {
+	output, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+	writer := zip.NewWriter(output)
+	for name, content := range files {
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := entry.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func zipProject(name, location, sparseCheckoutDir string) workspaces.Project {
+	return workspaces.Project{
+		Name: name,
+		ProjectSource: workspaces.ProjectSource{
+			Zip: &workspaces.ZipProjectSource{
+				CommonProjectSource: workspaces.CommonProjectSource{
+					SparseCheckoutDir: sparseCheckoutDir,
+				},
+				Location: location,
+			},
+		},
+	}
+}
+
+func TestExtractZip(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	archive := filepath.Join(tmpDir, "project.zip")
+	createZip(t, archive, map[string]string{
+		"README.md":          "# zip project",
+		"backend/":           "",
+		"backend/main.go":    "package main",
+		"backend-ui/app.js":  "console.log('app')",
+		"frontend/index.htm": "<html/>",
+	})
+
+	fetched, err := NewFetcher().FetchProject(zipProject("full", "file://"+archive, ""), tmpDir)
+	if assert.NoError(t, err) {
+		assertFiles(t, fetched.Path, "README.md", "backend/main.go", "backend-ui/app.js", "frontend/index.htm")
+		assert.Equal(t, "package main", readFile(t, filepath.Join(fetched.Path, "backend", "main.go")))
+	}
+
+	fetched, err = NewFetcher().FetchProject(zipProject("sparse", "file://"+archive, "/backend/"), tmpDir)
+	if assert.NoError(t, err) {
+		assertFiles(t, fetched.Path, "backend/main.go")
+	}
+
+	_, err = NewFetcher().FetchProject(zipProject("missing", archive, "docs"), tmpDir)
+	assert.EqualError(t, err, "failed to fetch project 'missing': sparse checkout directory 'docs' was not found in zip archive '"+archive+"'")
+
+	_, err = NewFetcher().FetchProject(zipProject("sparse", archive, ""), tmpDir)
+	assert.EqualError(t, err, "failed to fetch project 'sparse': target directory '"+filepath.Join(tmpDir, "sparse")+"' is not empty")
+}
+
+func TestZipEntriesOutsideOfProjectAreRejected(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	archive := filepath.Join(tmpDir, "malicious.zip")
+	createZip(t, archive, map[string]string{
+		"../../evil.sh": "rm -rf /",
+	})
+
+	_, err = NewFetcher().FetchProject(zipProject("malicious", archive, ""), filepath.Join(tmpDir, "projects"))
+	assert.EqualError(t, err, "failed to fetch project 'malicious': zip archive entry '../../evil.sh' would be extracted outside of the project directory")
+	_, statErr := os.Stat(filepath.Join(tmpDir, "evil.sh"))
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+func TestClonePathOutsideOfProjectsRootIsRejected(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	project := gitProject("escaping", map[string]string{"origin": "https://github.com/example/escaping.git"}, nil)
+	project.ClonePath = "../outside"
+	_, err = NewFetcher().FetchProject(project, filepath.Join(tmpDir, "projects"))
+	assert.EqualError(t, err, "failed to fetch project 'escaping': project 'escaping' has an invalid clone path '../outside': it should be a directory inside the projects root")
+	_, statErr := os.Stat(filepath.Join(tmpDir, "outside"))
+	assert.True(t, os.IsNotExist(statErr))
+}
@@ -0,0 +1,139 @@
+package projects
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+)
+
+// StarterProjectOptions are the options of a starter project instantiation
+type StarterProjectOptions struct {
+	// Name of the starter project to instantiate.
+	// Defaults to the first starter project of the devfile.
+	StarterProject string
+
+	// Whether the version control history of the starter project,
+	// such as the `.git` directory, should be removed.
+	StripHistory bool
+
+	// Whether the devfile content returned after the instantiation should declare
+	// the instantiated starter project as a regular project, instead of the starter projects.
+	ConvertToProject bool
+}
+
+// ListStarterProjects returns the starter projects of a devfile.
+//
+// The devfile content is expected to be flattened, for example with `overriding.MergeDevWorkspaceTemplateSpec`,
+// so that the starter projects inherited from the parent are part of the list.
+func ListStarterProjects(content *workspaces.DevWorkspaceTemplateSpecContent) []workspaces.StarterProject {
+	return append([]workspaces.StarterProject{}, content.StarterProjects...)
+}
+
+// FindStarterProject returns the starter project with the given name,
+// or the first starter project if the name is empty.
+func FindStarterProject(content *workspaces.DevWorkspaceTemplateSpecContent, name string) (*workspaces.StarterProject, error) {
+	if len(content.StarterProjects) == 0 {
+		return nil, fmt.Errorf("the devfile has no starter project")
+	}
+	if name == "" {
+		return content.StarterProjects[0].DeepCopy(), nil
+	}
+	for _, starterProject := range content.StarterProjects {
+		if starterProject.Name == name {
+			return starterProject.DeepCopy(), nil
+		}
+	}
+	return nil, fmt.Errorf("starter project '%s' is not defined in the devfile", name)
+}
+
+// InstantiateStarterProject downloads a starter project of a flattened devfile into `targetDir`,
+// which should either not exist or be empty.
+//
+// When the starter project has a `sparseCheckoutDir`, the content of this sub-directory
+// becomes the content of `targetDir`, and the version control history is always removed,
+// since it cannot describe the moved files.
+//
+// It returns the instantiated project, and a copy of the devfile content that,
+// if requested in the options, declares the starter project as its only project and has no starter projects anymore.
+func (f *Fetcher) InstantiateStarterProject(content *workspaces.DevWorkspaceTemplateSpecContent, targetDir string, options StarterProjectOptions) (*FetchedProject, *workspaces.DevWorkspaceTemplateSpecContent, error) {
+	starterProject, err := FindStarterProject(content, options.StarterProject)
+	if err != nil {
+		return nil, nil, err
+	}
+	target, err := filepath.Abs(targetDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ensureEmptyDirectory(target); err != nil {
+		return nil, nil, fmt.Errorf("failed to instantiate starter project '%s': %v", starterProject.Name, err)
+	}
+
+	// Fetch into a temporary sibling directory, so that files can then be moved
+	// into the target directory without crossing file systems.
+	tmpDir, err := ioutil.TempDir(filepath.Dir(target), ".starter-")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	project := starterProject.Project
+	project.ClonePath = ""
+	fetched, err := f.FetchProject(project, tmpDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sourceDir := fetched.Path
+	stripHistory := options.StripHistory
+	if sparseCheckoutDir := starterProjectSparseCheckoutDir(project); sparseCheckoutDir != "" {
+		sourceDir = filepath.Join(fetched.Path, filepath.FromSlash(sparseCheckoutDir))
+		stripHistory = true
+	}
+	if err := moveDirectoryContent(sourceDir, target, stripHistory); err != nil {
+		return nil, nil, fmt.Errorf("failed to instantiate starter project '%s': %v", starterProject.Name, err)
+	}
+	fetched.Path = target
+
+	result := content.DeepCopy()
+	if options.ConvertToProject {
+		result.Projects = []workspaces.Project{starterProject.Project}
+		result.StarterProjects = nil
+	}
+	return fetched, result, nil
+}
+
+func starterProjectSparseCheckoutDir(project workspaces.Project) string {
+	switch {
+	case project.Git != nil:
+		return project.Git.SparseCheckoutDir
+	case project.Github != nil:
+		return project.Github.SparseCheckoutDir
+	case project.Zip != nil:
+		return project.Zip.SparseCheckoutDir
+	}
+	return ""
+}
+
+// vcsDirectories are the version control directories removed when stripping the history of a project
+var vcsDirectories = map[string]bool{
+	".git": true,
+}
+
+func moveDirectoryContent(sourceDir, targetDir string, stripHistory bool) error {
+	entries, err := ioutil.ReadDir(sourceDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if stripHistory && entry.IsDir() && vcsDirectories[entry.Name()] {
+			continue
+		}
+		if err := os.Rename(filepath.Join(sourceDir, entry.Name()), filepath.Join(targetDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
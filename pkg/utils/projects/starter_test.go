@@ -0,0 +1,110 @@
+package projects
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/overriding"
+	"github.com/stretchr/testify/assert"
+)
+
+func starterProject(name, description, repository, sparseCheckoutDir string) workspaces.StarterProject {
+	project := gitProject(name, map[string]string{"origin": repository}, nil)
+	project.Git.SparseCheckoutDir = sparseCheckoutDir
+	return workspaces.StarterProject{
+		Project:     project,
+		Description: description,
+	}
+}
+
+func TestInstantiateStarterProjectInheritedFromParent(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "starter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	repository, _ := createBareRepository(t, tmpDir, "nodejs-starter")
+
+	main := &workspaces.DevWorkspaceTemplateSpecContent{
+		StarterProjects: []workspaces.StarterProject{
+			starterProject("web", "Web application", repository, ""),
+		},
+	}
+	parent := &workspaces.DevWorkspaceTemplateSpecContent{
+		StarterProjects: []workspaces.StarterProject{
+			starterProject("backend", "Backend only", repository, "backend"),
+		},
+	}
+	flattened, err := overriding.MergeDevWorkspaceTemplateSpec(main, parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	starterProjects := ListStarterProjects(flattened)
+	if assert.Len(t, starterProjects, 2) {
+		assert.Equal(t, "backend", starterProjects[0].Name)
+		assert.Equal(t, "web", starterProjects[1].Name)
+	}
+
+	target := filepath.Join(tmpDir, "workspace")
+	fetched, result, err := NewFetcher().InstantiateStarterProject(flattened, target, StarterProjectOptions{
+		StarterProject:   "backend",
+		ConvertToProject: true,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, target, fetched.Path)
+	assertFiles(t, target, "main.go")
+	_, statErr := os.Stat(filepath.Join(target, ".git"))
+	assert.True(t, os.IsNotExist(statErr), "history should be stripped when a sparse checkout directory is used")
+
+	assert.Empty(t, result.StarterProjects)
+	if assert.Len(t, result.Projects, 1) {
+		assert.Equal(t, "backend", result.Projects[0].Name)
+		assert.Equal(t, repository, result.Projects[0].Git.Remotes["origin"])
+	}
+	assert.Len(t, flattened.StarterProjects, 2, "the original devfile content should not be modified")
+
+	leftovers, _ := filepath.Glob(filepath.Join(tmpDir, ".starter-*"))
+	assert.Empty(t, leftovers)
+}
+
+func TestInstantiateDefaultStarterProject(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "starter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	repository, _ := createBareRepository(t, tmpDir, "nodejs-starter")
+	content := &workspaces.DevWorkspaceTemplateSpecContent{
+		StarterProjects: []workspaces.StarterProject{
+			starterProject("web", "Web application", repository, ""),
+		},
+	}
+
+	withHistory := filepath.Join(tmpDir, "with-history")
+	_, result, err := NewFetcher().InstantiateStarterProject(content, withHistory, StarterProjectOptions{})
+	if assert.NoError(t, err) {
+		assertFiles(t, withHistory, "README.md", "CHANGELOG.md", "backend/main.go")
+		assert.Equal(t, "main", runGit(t, withHistory, "rev-parse", "--abbrev-ref", "HEAD"))
+		assert.Equal(t, content, result)
+	}
+
+	withoutHistory := filepath.Join(tmpDir, "without-history")
+	_, _, err = NewFetcher().InstantiateStarterProject(content, withoutHistory, StarterProjectOptions{StripHistory: true})
+	if assert.NoError(t, err) {
+		assertFiles(t, withoutHistory, "README.md", "CHANGELOG.md", "backend/main.go")
+		_, statErr := os.Stat(filepath.Join(withoutHistory, ".git"))
+		assert.True(t, os.IsNotExist(statErr))
+	}
+
+	_, _, err = NewFetcher().InstantiateStarterProject(content, filepath.Join(tmpDir, "unknown"), StarterProjectOptions{StarterProject: "unknown"})
+	assert.EqualError(t, err, "starter project 'unknown' is not defined in the devfile")
+
+	_, _, err = NewFetcher().InstantiateStarterProject(&workspaces.DevWorkspaceTemplateSpecContent{}, filepath.Join(tmpDir, "none"), StarterProjectOptions{})
+	assert.EqualError(t, err, "the devfile has no starter project")
+}
@@ -0,0 +1,86 @@
+package projects
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/fetching"
+)
+
+// extractZipProject downloads the archive of a Zip project and extracts it into `target`.
+// When a `sparseCheckoutDir` is specified, only the entries of this sub-directory are extracted,
+// at the same relative location, as a Git sparse checkout would do.
+func (f *Fetcher) extractZipProject(source workspaces.ZipProjectSource, target string) error {
+	if source.Location == "" {
+		return fmt.Errorf("the location of the zip archive should be specified")
+	}
+	archiveFetcher := f.ArchiveFetcher
+	if archiveFetcher == nil {
+		archiveFetcher = fetching.DefaultFetcher
+	}
+	content, err := archiveFetcher.Fetch(source.Location)
+	if err != nil {
+		return err
+	}
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return fmt.Errorf("failed to read zip archive '%s': %v", source.Location, err)
+	}
+
+	prefix := ""
+	if source.SparseCheckoutDir != "" {
+		prefix = strings.Trim(path.Clean(filepath.ToSlash(source.SparseCheckoutDir)), "/") + "/"
+	}
+	extracted := 0
+	for _, file := range reader.File {
+		name := path.Clean(strings.TrimPrefix(file.Name, "/"))
+		if prefix != "" && !strings.HasPrefix(name+"/", prefix) {
+			continue
+		}
+		destination := filepath.Join(target, filepath.FromSlash(name))
+		if destination != target && !strings.HasPrefix(destination, target+string(filepath.Separator)) {
+			return fmt.Errorf("zip archive entry '%s' would be extracted outside of the project directory", file.Name)
+		}
+		if err := extractZipEntry(file, destination); err != nil {
+			return err
+		}
+		extracted++
+	}
+	if prefix != "" && extracted == 0 {
+		return fmt.Errorf("sparse checkout directory '%s' was not found in zip archive '%s'", source.SparseCheckoutDir, source.Location)
+	}
+	return nil
+}
+
+func extractZipEntry(file *zip.File, destination string) error {
+	if file.FileInfo().IsDir() {
+		return os.MkdirAll(destination, 0755)
+	}
+	if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+		return err
+	}
+	source, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	mode := file.Mode().Perm()
+	if mode == 0 {
+		mode = 0644
+	}
+	output, err := os.OpenFile(destination, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+	_, err = io.Copy(output, source)
+	return err
+}
@@ -0,0 +1,152 @@
+package resolving
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/credentials"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultLockfileName is the name of the lockfile expected next to a devfile.
+const DefaultLockfileName = "devfile.lock.yaml"
+
+// DigestPrefix is the prefix of the digests recorded in a lockfile.
+const DigestPrefix = "sha256:"
+
+// LockMode defines how the resolver uses its lockfile.
+type LockMode string
+
+const (
+	// VerifyLockMode verifies the digests of the imports already recorded in the lockfile,
+	// and records the imports that are not.
+	VerifyLockMode LockMode = "Verify"
+
+	// UpdateLockMode records the digests of all the imports again, replacing the previous entries,
+	// and removes the entries of imports that are not used anymore.
+	UpdateLockMode LockMode = "Update"
+)
+
+// Lockfile pins the parents and plugins referenced by `Uri` or `Id`
+// to the exact content they resolved to, so that a devfile always resolves the same way.
+//
+// `Kubernetes` references are not recorded, since they point to objects
+// of the cluster, that are not retrieved from a url.
+type Lockfile struct {
+	// Imports recorded in the lockfile, sorted by resolved url
+	Imports []LockedImport `json:"imports,omitempty"`
+}
+
+// LockedImport is the lockfile entry of a parent or plugin import
+type LockedImport struct {
+	// Url the import reference was resolved to, which identifies the entry.
+	ResolvedUrl string `json:"resolvedUrl"`
+
+	// Id of the import in the registry, for imports referenced by `Id`
+	// +optional
+	Id string `json:"id,omitempty"`
+
+	// Registry of the import, for imports referenced by `Id`
+	// +optional
+	RegistryUrl string `json:"registryUrl,omitempty"`
+
+	// Version of the imported devfile: the version chosen in the registry
+	// for imports with a version constraint, or else the version declared in its metadata
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// SHA-256 digest of the imported devfile content, in the `sha256:<hex>` form
+	Digest string `json:"digest"`
+}
+
+// ReadLockfile reads the lockfile at the given path.
+// An empty lockfile is returned if the file doesn't exist.
+func ReadLockfile(path string) (*Lockfile, error) {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Lockfile{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	lockfile := &Lockfile{}
+	if err := yaml.Unmarshal(content, lockfile); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile '%s': %v", path, err)
+	}
+	return lockfile, nil
+}
+
+// Write writes the lockfile to the given path.
+func (l *Lockfile) Write(path string) error {
+	content, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, 0644)
+}
+
+// Find returns the entry of the import resolved to the given url,
+// or nil if there is none.
+func (l *Lockfile) Find(resolvedUrl string) *LockedImport {
+	for i := range l.Imports {
+		if l.Imports[i].ResolvedUrl == resolvedUrl {
+			return &l.Imports[i]
+		}
+	}
+	return nil
+}
+
+func (l *Lockfile) record(entry LockedImport) {
+	if existing := l.Find(entry.ResolvedUrl); existing != nil {
+		*existing = entry
+		return
+	}
+	l.Imports = append(l.Imports, entry)
+	sort.Slice(l.Imports, func(i, j int) bool {
+		return l.Imports[i].ResolvedUrl < l.Imports[j].ResolvedUrl
+	})
+}
+
+// Digest returns the digest of a devfile content, as recorded in a lockfile.
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return DigestPrefix + hex.EncodeToString(sum[:])
+}
+
+// lock verifies or records the lockfile entry of the content fetched for an import reference.
+func (r *Resolver) lock(reference workspaces.ImportReference, resolvedUrl string, version string, content []byte) error {
+	if r.Lockfile == nil {
+		return nil
+	}
+	resolvedUrl = credentials.RedactURL(resolvedUrl)
+	digest := Digest(content)
+	if r.LockMode != UpdateLockMode {
+		if locked := r.Lockfile.Find(resolvedUrl); locked != nil {
+			if locked.Digest != digest {
+				return fmt.Errorf("the content of '%s' doesn't match the lockfile: expected digest '%s', but got '%s'",
+					resolvedUrl, locked.Digest, digest)
+			}
+			return nil
+		}
+	}
+
+	entry := LockedImport{
+		ResolvedUrl: resolvedUrl,
+		Version:     version,
+		Digest:      digest,
+	}
+	if reference.Id != "" {
+		entry.Id = reference.Id
+		entry.RegistryUrl = reference.RegistryUrl
+		if entry.RegistryUrl == "" {
+			entry.RegistryUrl = r.DefaultRegistryUrl
+		}
+	}
+	r.Lockfile.record(entry)
+	return nil
+}
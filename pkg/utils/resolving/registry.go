@@ -0,0 +1,134 @@
+package resolving
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/credentials"
+	"github.com/devfile/api/pkg/utils/fetching"
+	"github.com/devfile/api/pkg/utils/semver"
+)
+
+// RegistryIndexEntry is an entry of the index of a devfile registry,
+// available at `<registryUrl>/index`.
+type RegistryIndexEntry struct {
+	// Id of the devfile in the registry
+	Name string `json:"name"`
+
+	// Versions of the devfile available in the registry
+	Versions []RegistryVersion `json:"versions,omitempty"`
+}
+
+// RegistryVersion is a version of a devfile available in a registry
+type RegistryVersion struct {
+	Version string `json:"version"`
+}
+
+// fetchFromRegistry retrieves the devfile referenced by id.
+//
+// Without a version constraint, the default version is retrieved from `<registryUrl>/devfiles/<id>`.
+// With a version constraint, the highest matching version listed in the registry index
+// is retrieved from `<registryUrl>/devfiles/<id>/<version>`. When a lockfile is used in `VerifyLockMode`,
+// the locked version is kept as long as it still satisfies the constraint.
+func (r *Resolver) fetchFromRegistry(reference workspaces.ImportReference) (*ResolvedReference, error) {
+	registryUrl := reference.RegistryUrl
+	if registryUrl == "" {
+		registryUrl = r.DefaultRegistryUrl
+	}
+	if registryUrl == "" {
+		return nil, fmt.Errorf("cannot resolve id '%s': no registry url is defined", reference.Id)
+	}
+	devfileUrl := strings.TrimSuffix(registryUrl, "/") + "/devfiles/" + reference.Id
+	if reference.Version == "" {
+		return r.fetch(reference, devfileUrl, "")
+	}
+
+	constraint, err := semver.ParseConstraint(reference.Version)
+	if err != nil {
+		return nil, err
+	}
+	version, found := r.lockedVersion(reference, registryUrl, constraint)
+	if !found {
+		versions, err := r.registryVersions(reference, registryUrl)
+		if err != nil {
+			return nil, err
+		}
+		if version, found = constraint.MaxSatisfying(versions); !found {
+			return nil, fmt.Errorf("no version of '%s' in registry '%s' satisfies the '%s' constraint (available versions: %s)",
+				reference.Id, credentials.RedactURL(registryUrl), reference.Version, strings.Join(versions, ", "))
+		}
+	}
+	return r.fetch(reference, devfileUrl+"/"+version, version)
+}
+
+func (r *Resolver) lockedVersion(reference workspaces.ImportReference, registryUrl string, constraint *semver.Constraint) (string, bool) {
+	if r.Lockfile == nil || r.LockMode == UpdateLockMode {
+		return "", false
+	}
+	for _, locked := range r.Lockfile.Imports {
+		if locked.Id != reference.Id || locked.RegistryUrl != credentials.RedactURL(registryUrl) {
+			continue
+		}
+		if _, found := constraint.MaxSatisfying([]string{locked.Version}); found {
+			return locked.Version, true
+		}
+	}
+	return "", false
+}
+
+func (r *Resolver) registryVersions(reference workspaces.ImportReference, registryUrl string) ([]string, error) {
+	indexUrl := strings.TrimSuffix(registryUrl, "/") + "/index"
+	creds, err := r.credentialsFor(reference, indexUrl)
+	if err != nil {
+		return nil, err
+	}
+	content, err := fetching.FetchWithCredentials(r.fetcher(), indexUrl, creds)
+	if err != nil {
+		return nil, creds.RedactError(err)
+	}
+	var index []RegistryIndexEntry
+	if err := json.Unmarshal(content, &index); err != nil {
+		return nil, creds.RedactError(fmt.Errorf("failed to parse the registry index at '%s': %v", credentials.RedactURL(indexUrl), err))
+	}
+	for _, entry := range index {
+		if entry.Name != reference.Id {
+			continue
+		}
+		var versions []string
+		for _, version := range entry.Versions {
+			versions = append(versions, version.Version)
+		}
+		return versions, nil
+	}
+	return nil, fmt.Errorf("'%s' was not found in the index of registry '%s'", reference.Id, credentials.RedactURL(registryUrl))
+}
+
+// MajorVersionWarnings compares the provenance of the imports of a devfile, as stored
+// in the status of a workspace, with the provenance obtained when flattening the devfile again,
+// and returns a warning for each import whose major version changed.
+//
+// Imports are matched by path, and imports without a valid semantic version are ignored.
+func MajorVersionWarnings(previous, current []workspaces.ImportProvenance) []string {
+	previousVersions := map[string]string{}
+	for _, provenance := range previous {
+		previousVersions[provenance.Path] = provenance.Version
+	}
+	var warnings []string
+	for _, provenance := range current {
+		previousVersion, err := semver.Parse(previousVersions[provenance.Path])
+		if err != nil {
+			continue
+		}
+		currentVersion, err := semver.Parse(provenance.Version)
+		if err != nil {
+			continue
+		}
+		if previousVersion.Major != currentVersion.Major {
+			warnings = append(warnings, fmt.Sprintf("the major version of import '%s' changed from %s to %s, which may bring breaking changes",
+				provenance.Path, previousVersion, currentVersion))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,283 @@
+package resolving
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/credentials"
+	"github.com/devfile/api/pkg/utils/fetching"
+	"github.com/devfile/api/pkg/utils/overriding"
+	"sigs.k8s.io/yaml"
+)
+
+// TemplateGetter retrieves the `DevWorkspaceTemplate` custom resource
+// with the given name and namespace.
+type TemplateGetter func(name, namespace string) (*workspaces.DevWorkspaceTemplate, error)
+
+// Resolver resolves the import references of parents and plugins,
+// and flattens a devfile by applying the overrides of its parent and plugins.
+type Resolver struct {
+	// Fetcher used to retrieve `Uri` and `Id` references.
+	// When nil, `fetching.DefaultFetcher` is used.
+	Fetcher fetching.Fetcher
+
+	// GetTemplate retrieves `Kubernetes` references.
+	// When nil, `Kubernetes` references cannot be resolved.
+	GetTemplate TemplateGetter
+
+	// Namespace of `Kubernetes` references that don't specify one.
+	Namespace string
+
+	// DefaultRegistryUrl is the registry used for `Id` references
+	// that don't specify a `registryUrl`.
+	DefaultRegistryUrl string
+
+	// Credentials provides the credentials referenced by import references.
+	// When nil, import references that require credentials cannot be resolved.
+	Credentials credentials.Provider
+
+	// Lockfile in which the digests of the `Uri` and `Id` imports are verified or recorded.
+	// When nil, imports are not pinned.
+	Lockfile *Lockfile
+
+	// LockMode defines how the lockfile is used.
+	// Defaults to `VerifyLockMode`.
+	LockMode LockMode
+}
+
+// ResolvedReference is the devfile an import reference points to.
+type ResolvedReference struct {
+	// Location of the devfile: the resolved uri for `Uri` and `Id` references,
+	// or `<namespace>/<name>` for `Kubernetes` references.
+	Location string
+
+	// Version of the devfile: the version chosen in the registry for references
+	// with a version constraint, or else the version declared in the devfile metadata.
+	Version string
+
+	// Content is the raw content of the devfile retrieved for `Uri` and `Id` references.
+	// It is nil for `Kubernetes` references.
+	Content []byte
+
+	// Spec is the devfile content, not flattened yet.
+	Spec *workspaces.DevWorkspaceTemplateSpec
+}
+
+// kubernetesBasePrefix prefixes the base of the devfiles of `Kubernetes` references.
+// Such devfiles have no location against which relative uris could be resolved,
+// and should not read local files, so they can only reference absolute `http` and `https` uris.
+const kubernetesBasePrefix = "kubernetes:"
+
+// ResolveReference retrieves the devfile that an import reference points to.
+//
+// Relative uris are resolved against `base`, which is the location of the devfile
+// that contains the reference. When a lockfile is set, the content retrieved
+// for `Uri` and `Id` references is verified against it, or recorded in it.
+// Secrets of the referenced credentials are redacted from the returned errors.
+func (r *Resolver) ResolveReference(reference workspaces.ImportReference, base string) (*ResolvedReference, error) {
+	if reference.Version != "" && reference.Id == "" {
+		return nil, fmt.Errorf("version constraint '%s' is only supported for imports referenced by id", reference.Version)
+	}
+	switch {
+	case reference.Uri != "" && strings.HasPrefix(base, kubernetesBasePrefix):
+		parsed, err := url.Parse(reference.Uri)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return nil, fmt.Errorf("invalid uri '%s': the Kubernetes resource '%s' can only reference absolute http or https uris", credentials.RedactURL(reference.Uri), strings.TrimPrefix(base, kubernetesBasePrefix))
+		}
+		return r.fetch(reference, reference.Uri, "")
+	case reference.Uri != "":
+		uri, err := fetching.ResolveURI(base, reference.Uri)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uri '%s': %v", credentials.RedactURL(reference.Uri), err)
+		}
+		return r.fetch(reference, uri, "")
+	case reference.Id != "":
+		return r.fetchFromRegistry(reference)
+	case reference.Kubernetes != nil:
+		return r.getTemplate(*reference.Kubernetes)
+	}
+	return nil, fmt.Errorf("the import reference defines neither a uri, an id nor a Kubernetes resource")
+}
+
+func (r *Resolver) credentialsFor(reference workspaces.ImportReference, uri string) (*credentials.Credentials, error) {
+	if reference.Credentials == nil {
+		return nil, nil
+	}
+	if r.Credentials == nil {
+		return nil, fmt.Errorf("'%s' references credentials, but no credentials provider is configured", credentials.RedactURL(uri))
+	}
+	return r.Credentials.Credentials(*reference.Credentials)
+}
+
+func (r *Resolver) fetcher() fetching.Fetcher {
+	if r.Fetcher == nil {
+		return fetching.DefaultFetcher
+	}
+	return r.Fetcher
+}
+
+// fetch retrieves the devfile at the given uri.
+// `version` is the version chosen in the registry, if any.
+func (r *Resolver) fetch(reference workspaces.ImportReference, uri string, version string) (*ResolvedReference, error) {
+	creds, err := r.credentialsFor(reference, uri)
+	if err != nil {
+		return nil, err
+	}
+	content, err := fetching.FetchWithCredentials(r.fetcher(), uri, creds)
+	if err != nil {
+		return nil, creds.RedactError(err)
+	}
+	spec := &workspaces.DevWorkspaceTemplateSpec{}
+	if err := yaml.Unmarshal(content, spec); err != nil {
+		return nil, creds.RedactError(fmt.Errorf("failed to parse the devfile at '%s': %v", credentials.RedactURL(uri), err))
+	}
+	if version == "" {
+		metadata := devfileMetadata{}
+		if err := yaml.Unmarshal(content, &metadata); err == nil {
+			version = metadata.Metadata.Version
+		}
+	}
+	if err := r.lock(reference, uri, version, content); err != nil {
+		return nil, creds.RedactError(err)
+	}
+	return &ResolvedReference{
+		Location: uri,
+		Version:  version,
+		Content:  content,
+		Spec:     spec,
+	}, nil
+}
+
+type devfileMetadata struct {
+	Metadata struct {
+		Version string `json:"version,omitempty"`
+	} `json:"metadata,omitempty"`
+}
+
+func (r *Resolver) getTemplate(reference workspaces.KubernetesCustomResourceImportReference) (*ResolvedReference, error) {
+	if r.GetTemplate == nil {
+		return nil, fmt.Errorf("cannot resolve Kubernetes reference '%s': no template getter is configured", reference.Name)
+	}
+	namespace := reference.Namespace
+	if namespace == "" {
+		namespace = r.Namespace
+	}
+	template, err := r.GetTemplate(reference.Name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &ResolvedReference{
+		Location: namespace + "/" + reference.Name,
+		Spec:     template.Spec.DeepCopy(),
+	}, nil
+}
+
+// Flattened is the result of flattening a devfile.
+type Flattened struct {
+	// Content of the devfile, merged with the content of its parent and plugins
+	Content *workspaces.DevWorkspaceTemplateSpecContent
+
+	// Provenance of all the parents and plugins in the import tree of the devfile
+	Imports []workspaces.ImportProvenance
+}
+
+// Flatten resolves the parent and plugins of a devfile, recursively, applies their overrides,
+// and merges them with the main devfile content.
+//
+// `base` is the location of the devfile, against which relative uris are resolved.
+// Returns an error if a devfile directly or indirectly imports itself.
+//
+// In `UpdateLockMode`, the lockfile entries are replaced by the imports of the flattened devfile.
+func (r *Resolver) Flatten(spec *workspaces.DevWorkspaceTemplateSpec, base string) (*Flattened, error) {
+	if r.Lockfile != nil && r.LockMode == UpdateLockMode {
+		r.Lockfile.Imports = nil
+	}
+	result := &Flattened{}
+	content, err := r.flatten(spec, base, []string{base}, "", &result.Imports)
+	if err != nil {
+		return nil, err
+	}
+	result.Content = content
+	return result, nil
+}
+
+// FlattenDevWorkspaceTemplateSpec flattens a devfile as `Flatten` does,
+// and only returns the flattened content.
+func (r *Resolver) FlattenDevWorkspaceTemplateSpec(spec *workspaces.DevWorkspaceTemplateSpec, base string) (*workspaces.DevWorkspaceTemplateSpecContent, error) {
+	flattened, err := r.Flatten(spec, base)
+	if err != nil {
+		return nil, err
+	}
+	return flattened.Content, nil
+}
+
+func (r *Resolver) flatten(spec *workspaces.DevWorkspaceTemplateSpec, base string, importChain []string, path string, imports *[]workspaces.ImportProvenance) (*workspaces.DevWorkspaceTemplateSpecContent, error) {
+	mainContent := spec.DevWorkspaceTemplateSpecContent.DeepCopy()
+
+	parentContent := &workspaces.DevWorkspaceTemplateSpecContent{}
+	if spec.Parent != nil {
+		flattened, err := r.flattenImport(spec.Parent.ImportReference, spec.Parent.ParentOverrides, base, importChain, path+"parent", imports)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the parent: %v", err)
+		}
+		parentContent = flattened
+	}
+
+	var pluginContents []*workspaces.DevWorkspaceTemplateSpecContent
+	var components []workspaces.Component
+	for _, component := range mainContent.Components {
+		if component.Plugin == nil {
+			components = append(components, component)
+			continue
+		}
+		flattened, err := r.flattenImport(component.Plugin.ImportReference, component.Plugin.PluginOverrides, base, importChain, path+"plugin:"+component.Key(), imports)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve plugin '%s': %v", component.Key(), err)
+		}
+		pluginContents = append(pluginContents, flattened)
+	}
+	mainContent.Components = components
+
+	return overriding.MergeDevWorkspaceTemplateSpec(mainContent, parentContent, pluginContents...)
+}
+
+func (r *Resolver) flattenImport(reference workspaces.ImportReference, overrides workspaces.Overrides, base string, importChain []string, path string, imports *[]workspaces.ImportProvenance) (*workspaces.DevWorkspaceTemplateSpecContent, error) {
+	resolved, err := r.ResolveReference(reference, base)
+	if err != nil {
+		return nil, err
+	}
+	for _, location := range importChain {
+		if location == resolved.Location {
+			return nil, fmt.Errorf("import cycle detected: %s -> %s", strings.Join(importChain, " -> "), resolved.Location)
+		}
+	}
+	*imports = append(*imports, workspaces.ImportProvenance{
+		Path:              path,
+		Location:          credentials.RedactURL(resolved.Location),
+		Version:           resolved.Version,
+		VersionConstraint: reference.Version,
+	})
+	nestedBase := resolved.Location
+	if reference.Kubernetes != nil {
+		nestedBase = kubernetesBasePrefix + resolved.Location
+	}
+	flattened, err := r.flatten(resolved.Spec, nestedBase, append(importChain, resolved.Location), path+"/", imports)
+	if err != nil {
+		return nil, err
+	}
+	if !hasOverrides(overrides) {
+		return flattened, nil
+	}
+	return overriding.OverrideDevWorkspaceTemplateSpec(flattened, overrides)
+}
+
+func hasOverrides(overrides workspaces.Overrides) bool {
+	for _, list := range overrides.GetToplevelLists() {
+		if len(list) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,355 @@
+package resolving
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/credentials"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+const registryToken = "s3cr3t-t0k3n"
+
+func readFixture(t *testing.T, name string, into interface{}) {
+	content, err := ioutil.ReadFile(filepath.Join("test-fixtures", name))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if !assert.NoError(t, yaml.Unmarshal(content, into)) {
+		t.FailNow()
+	}
+}
+
+func newRegistry(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+registryToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var content []byte
+		switch r.URL.Path {
+		case "/devfiles/devfile/tools":
+			var err error
+			content, err = ioutil.ReadFile(filepath.Join("test-fixtures", "tools.devfile.yaml"))
+			assert.NoError(t, err)
+		case "/index":
+			content = []byte(`[{"name": "devfile/tools", "versions": []}, {"name": "nodejs-stack", "versions": [{"version": "1.4.0"}, {"version": "2.0.0"}, {"version": "2.3.1"}, {"version": "3.0.0-rc.1"}]}]`)
+		case "/devfiles/nodejs-stack/1.4.0", "/devfiles/nodejs-stack/2.0.0", "/devfiles/nodejs-stack/2.3.1", "/devfiles/nodejs-stack/3.0.0-rc.1":
+			version := path.Base(r.URL.Path)
+			content = []byte(fmt.Sprintf("schemaVersion: 2.0.0\nmetadata:\n  name: nodejs-stack\n  version: %s\ncomponents:\n  - name: nodejs\n    container:\n      image: quay.io/devfile/nodejs:%s\n", version, version))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+}
+
+func newResolver(t *testing.T, registryUrl string, token string) *Resolver {
+	return &Resolver{
+		DefaultRegistryUrl: registryUrl,
+		Namespace:          "devworkspaces",
+		GetTemplate: func(name, namespace string) (*workspaces.DevWorkspaceTemplate, error) {
+			template := &workspaces.DevWorkspaceTemplate{}
+			readFixture(t, name+".yaml", template)
+			if template.Namespace != namespace {
+				return nil, fmt.Errorf("template '%s' not found in namespace '%s'", name, namespace)
+			}
+			return template, nil
+		},
+		Credentials: credentials.ProviderFunc(func(reference workspaces.CredentialsReference) (*credentials.Credentials, error) {
+			if reference.SecretName != "registry-token" {
+				return nil, fmt.Errorf("secret '%s' not found", reference.SecretName)
+			}
+			return &credentials.Credentials{Token: token}, nil
+		}),
+	}
+}
+
+func TestFlattenDevWorkspaceTemplateSpec(t *testing.T) {
+	registry := newRegistry(t)
+	defer registry.Close()
+	resolver := newResolver(t, registry.URL, registryToken)
+
+	spec := &workspaces.DevWorkspaceTemplateSpec{}
+	readFixture(t, "main.devfile.yaml", spec)
+	expected := &workspaces.DevWorkspaceTemplateSpecContent{}
+	readFixture(t, "flattened.yaml", expected)
+
+	flattened, err := resolver.FlattenDevWorkspaceTemplateSpec(spec, filepath.Join("test-fixtures", "main.devfile.yaml"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, flattened)
+	}
+}
+
+func TestInvalidCredentialsAreRedacted(t *testing.T) {
+	registry := newRegistry(t)
+	defer registry.Close()
+	resolver := newResolver(t, registry.URL, "wr0ng-t0k3n")
+
+	_, err := resolver.ResolveReference(workspaces.ImportReference{
+		ImportReferenceUnion: workspaces.ImportReferenceUnion{
+			Uri: "http://user:wr0ng-t0k3n@" + registry.Listener.Addr().String() + "/devfiles/devfile/tools",
+		},
+		Credentials: &workspaces.CredentialsReference{SecretName: "registry-token"},
+	}, "")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "401 Unauthorized")
+		assert.NotContains(t, err.Error(), "wr0ng-t0k3n")
+	}
+}
+
+func TestCredentialsRequireAProvider(t *testing.T) {
+	resolver := &Resolver{}
+	_, err := resolver.ResolveReference(workspaces.ImportReference{
+		ImportReferenceUnion: workspaces.ImportReferenceUnion{
+			Uri: "https://registry.example.com/devfiles/devfile/tools",
+		},
+		Credentials: &workspaces.CredentialsReference{SecretName: "registry-token"},
+	}, "")
+	if assert.Error(t, err) {
+		assert.Equal(t, "'https://registry.example.com/devfiles/devfile/tools' references credentials, but no credentials provider is configured", err.Error())
+	}
+}
+
+func TestImportCycleIsRejected(t *testing.T) {
+	resolver := &Resolver{}
+	spec := &workspaces.DevWorkspaceTemplateSpec{}
+	readFixture(t, "cycle-a.devfile.yaml", spec)
+
+	base := filepath.Join("test-fixtures", "cycle-a.devfile.yaml")
+	_, err := resolver.FlattenDevWorkspaceTemplateSpec(spec, base)
+	if assert.Error(t, err) {
+		cycleB := filepath.Join("test-fixtures", "cycle-b.devfile.yaml")
+		assert.Equal(t,
+			fmt.Sprintf("failed to resolve the parent: failed to resolve the parent: import cycle detected: %s -> %s -> %s", base, cycleB, base),
+			err.Error())
+	}
+}
+
+func TestLockfileRecordsAndVerifiesImports(t *testing.T) {
+	registry := newRegistry(t)
+	defer registry.Close()
+	resolver := newResolver(t, registry.URL, registryToken)
+	resolver.Lockfile = &Lockfile{}
+
+	spec := &workspaces.DevWorkspaceTemplateSpec{}
+	readFixture(t, "main.devfile.yaml", spec)
+	base := filepath.Join("test-fixtures", "main.devfile.yaml")
+
+	_, err := resolver.FlattenDevWorkspaceTemplateSpec(spec, base)
+	if !assert.NoError(t, err) {
+		return
+	}
+	toolsContent, err := ioutil.ReadFile(filepath.Join("test-fixtures", "tools.devfile.yaml"))
+	assert.NoError(t, err)
+	parentContent, err := ioutil.ReadFile(filepath.Join("test-fixtures", "parent.devfile.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, []LockedImport{
+		{
+			ResolvedUrl: registry.URL + "/devfiles/devfile/tools",
+			Id:          "devfile/tools",
+			RegistryUrl: registry.URL,
+			Version:     "1.2.0",
+			Digest:      Digest(toolsContent),
+		},
+		{
+			ResolvedUrl: filepath.Join("test-fixtures", "parent.devfile.yaml"),
+			Digest:      Digest(parentContent),
+		},
+	}, resolver.Lockfile.Imports)
+
+	tmpDir, err := ioutil.TempDir("", "resolving")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(tmpDir)
+	lockfilePath := filepath.Join(tmpDir, DefaultLockfileName)
+	assert.NoError(t, resolver.Lockfile.Write(lockfilePath))
+	lockfile, err := ReadLockfile(lockfilePath)
+	if assert.NoError(t, err) {
+		assert.Equal(t, resolver.Lockfile, lockfile)
+	}
+
+	_, err = resolver.FlattenDevWorkspaceTemplateSpec(spec, base)
+	assert.NoError(t, err, "unchanged imports should match the lockfile")
+
+	resolver.Lockfile.Imports[0].Digest = Digest([]byte("previous content"))
+	_, err = resolver.FlattenDevWorkspaceTemplateSpec(spec, base)
+	if assert.Error(t, err) {
+		assert.Equal(t, fmt.Sprintf("failed to resolve plugin 'tools': the content of '%s/devfiles/devfile/tools' doesn't match the lockfile: expected digest '%s', but got '%s'",
+			registry.URL, Digest([]byte("previous content")), Digest(toolsContent)), err.Error())
+	}
+
+	resolver.LockMode = UpdateLockMode
+	resolver.Lockfile.Imports = append(resolver.Lockfile.Imports, LockedImport{ResolvedUrl: "https://example.com/unused.yaml", Digest: Digest(nil)})
+	_, err = resolver.FlattenDevWorkspaceTemplateSpec(spec, base)
+	if assert.NoError(t, err) {
+		assert.Equal(t, lockfile.Imports, resolver.Lockfile.Imports, "update mode should record the new digests and remove unused entries")
+	}
+}
+
+func TestMissingLockfileIsEmpty(t *testing.T) {
+	lockfile, err := ReadLockfile(filepath.Join("test-fixtures", DefaultLockfileName))
+	if assert.NoError(t, err) {
+		assert.Equal(t, &Lockfile{}, lockfile)
+	}
+}
+
+func nodejsStackChild(version string) *workspaces.DevWorkspaceTemplateSpec {
+	return &workspaces.DevWorkspaceTemplateSpec{
+		Parent: &workspaces.Parent{
+			ImportReference: workspaces.ImportReference{
+				ImportReferenceUnion: workspaces.ImportReferenceUnion{
+					Id: "nodejs-stack",
+				},
+				Version:     version,
+				Credentials: &workspaces.CredentialsReference{SecretName: "registry-token"},
+			},
+		},
+	}
+}
+
+func TestVersionConstraintSelectsTheHighestMatchingVersion(t *testing.T) {
+	registry := newRegistry(t)
+	defer registry.Close()
+	resolver := newResolver(t, registry.URL, registryToken)
+
+	flattened, err := resolver.Flatten(nodejsStackChild("2.x"), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "quay.io/devfile/nodejs:2.3.1", flattened.Content.Components[0].Container.Image)
+	assert.Equal(t, []workspaces.ImportProvenance{
+		{
+			Path:              "parent",
+			Location:          registry.URL + "/devfiles/nodejs-stack/2.3.1",
+			Version:           "2.3.1",
+			VersionConstraint: "2.x",
+		},
+	}, flattened.Imports)
+
+	_, err = resolver.Flatten(nodejsStackChild("^4.0.0"), "")
+	if assert.Error(t, err) {
+		assert.Equal(t, fmt.Sprintf("failed to resolve the parent: no version of 'nodejs-stack' in registry '%s' satisfies the '^4.0.0' constraint (available versions: 1.4.0, 2.0.0, 2.3.1, 3.0.0-rc.1)", registry.URL), err.Error())
+	}
+}
+
+func TestLockedVersionIsKeptUntilUpdate(t *testing.T) {
+	registry := newRegistry(t)
+	defer registry.Close()
+	resolver := newResolver(t, registry.URL, registryToken)
+	resolver.Lockfile = &Lockfile{}
+
+	_, err := resolver.Flatten(nodejsStackChild("~2.0.0"), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	flattened, err := resolver.Flatten(nodejsStackChild(">=2.0.0 <3.0.0"), "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "2.0.0", flattened.Imports[0].Version, "the locked version should be kept while it satisfies the constraint")
+	}
+
+	resolver.LockMode = UpdateLockMode
+	flattened, err = resolver.Flatten(nodejsStackChild(">=2.0.0 <3.0.0"), "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "2.3.1", flattened.Imports[0].Version)
+		if assert.Len(t, resolver.Lockfile.Imports, 1) {
+			assert.Equal(t, "2.3.1", resolver.Lockfile.Imports[0].Version)
+			assert.Equal(t, "nodejs-stack", resolver.Lockfile.Imports[0].Id)
+		}
+	}
+}
+
+func TestVersionConstraintRequiresAnId(t *testing.T) {
+	resolver := &Resolver{}
+	_, err := resolver.ResolveReference(workspaces.ImportReference{
+		ImportReferenceUnion: workspaces.ImportReferenceUnion{
+			Uri: "https://registry.example.com/devfiles/nodejs-stack",
+		},
+		Version: "2.x",
+	}, "")
+	if assert.Error(t, err) {
+		assert.Equal(t, "version constraint '2.x' is only supported for imports referenced by id", err.Error())
+	}
+}
+
+func TestProvenanceOfNestedImports(t *testing.T) {
+	registry := newRegistry(t)
+	defer registry.Close()
+	resolver := newResolver(t, registry.URL, registryToken)
+
+	spec := &workspaces.DevWorkspaceTemplateSpec{}
+	readFixture(t, "main.devfile.yaml", spec)
+	flattened, err := resolver.Flatten(spec, filepath.Join("test-fixtures", "main.devfile.yaml"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []workspaces.ImportProvenance{
+			{Path: "parent", Location: filepath.Join("test-fixtures", "parent.devfile.yaml")},
+			{Path: "plugin:tools", Location: registry.URL + "/devfiles/devfile/tools", Version: "1.2.0"},
+			{Path: "plugin:database", Location: "devworkspaces/database"},
+		}, flattened.Imports)
+	}
+}
+
+func TestMajorVersionWarnings(t *testing.T) {
+	previous := []workspaces.ImportProvenance{
+		{Path: "parent", Version: "1.4.0"},
+		{Path: "parent/plugin:tools", Version: "2.0.0"},
+		{Path: "plugin:database", Version: "latest"},
+	}
+	current := []workspaces.ImportProvenance{
+		{Path: "parent", Version: "2.3.1"},
+		{Path: "parent/plugin:tools", Version: "2.1.0"},
+		{Path: "plugin:database", Version: "3.0.0"},
+		{Path: "plugin:new", Version: "1.0.0"},
+	}
+	assert.Equal(t, []string{
+		"the major version of import 'parent' changed from 1.4.0 to 2.3.1, which may bring breaking changes",
+	}, MajorVersionWarnings(previous, current))
+}
+
+func TestKubernetesTemplatesOnlyImportRemoteUris(t *testing.T) {
+	registry := newRegistry(t)
+	defer registry.Close()
+	resolver := newResolver(t, registry.URL, registryToken)
+	parentUri := ""
+	resolver.GetTemplate = func(name, namespace string) (*workspaces.DevWorkspaceTemplate, error) {
+		template := &workspaces.DevWorkspaceTemplate{}
+		template.Spec.Parent = &workspaces.Parent{
+			ImportReference: workspaces.ImportReference{
+				ImportReferenceUnion: workspaces.ImportReferenceUnion{Uri: parentUri},
+				Credentials:          &workspaces.CredentialsReference{SecretName: "registry-token"},
+			},
+		}
+		return template, nil
+	}
+	spec := &workspaces.DevWorkspaceTemplateSpec{
+		Parent: &workspaces.Parent{
+			ImportReference: workspaces.ImportReference{
+				ImportReferenceUnion: workspaces.ImportReferenceUnion{
+					Kubernetes: &workspaces.KubernetesCustomResourceImportReference{Name: "base"},
+				},
+			},
+		},
+	}
+
+	for _, uri := range []string{"parent.devfile.yaml", "/home/user/.kube/config", "file:///home/user/.kube/config"} {
+		parentUri = uri
+		_, err := resolver.FlattenDevWorkspaceTemplateSpec(spec, filepath.Join("test-fixtures", "main.devfile.yaml"))
+		assert.EqualError(t, err, "failed to resolve the parent: failed to resolve the parent: invalid uri '"+uri+"': the Kubernetes resource 'devworkspaces/base' can only reference absolute http or https uris")
+	}
+
+	parentUri = registry.URL + "/devfiles/devfile/tools"
+	flattened, err := resolver.FlattenDevWorkspaceTemplateSpec(spec, filepath.Join("test-fixtures", "main.devfile.yaml"))
+	if assert.NoError(t, err) {
+		assert.NotEmpty(t, flattened.Components)
+	}
+}
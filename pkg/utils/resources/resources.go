@@ -0,0 +1,182 @@
+// Package resources computes the compute resources (CPU and memory) required by workspaces,
+// for example to check them against the resource quota of a namespace before starting a workspace.
+package resources
+
+import (
+	"fmt"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ContainerResources returns the resource requirements of a devfile container.
+//
+// As in Kubernetes, a request that is omitted defaults to the corresponding limit.
+// An error is returned if a value is not a valid resource quantity,
+// is negative, or if a request is greater than the corresponding limit.
+func ContainerResources(container workspaces.Container) (corev1.ResourceRequirements, error) {
+	var errors *multierror.Error
+	requirements := corev1.ResourceRequirements{
+		Limits:   corev1.ResourceList{},
+		Requests: corev1.ResourceList{},
+	}
+
+	parse := func(field, value string, list corev1.ResourceList, name corev1.ResourceName) {
+		if value == "" {
+			return
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			errors = multierror.Append(errors, fmt.Errorf("%s '%s' is not a valid resource quantity", field, value))
+			return
+		}
+		if quantity.Sign() < 0 {
+			errors = multierror.Append(errors, fmt.Errorf("%s '%s' should not be negative", field, value))
+			return
+		}
+		list[name] = quantity
+	}
+	parse("memoryLimit", container.MemoryLimit, requirements.Limits, corev1.ResourceMemory)
+	parse("memoryRequest", container.MemoryRequest, requirements.Requests, corev1.ResourceMemory)
+	parse("cpuLimit", container.CpuLimit, requirements.Limits, corev1.ResourceCPU)
+	parse("cpuRequest", container.CpuRequest, requirements.Requests, corev1.ResourceCPU)
+	if errors != nil {
+		return corev1.ResourceRequirements{}, errors
+	}
+
+	for _, name := range []corev1.ResourceName{corev1.ResourceMemory, corev1.ResourceCPU} {
+		limit, hasLimit := requirements.Limits[name]
+		request, hasRequest := requirements.Requests[name]
+		switch {
+		case hasLimit && !hasRequest:
+			requirements.Requests[name] = limit.DeepCopy()
+		case hasLimit && hasRequest && request.Cmp(limit) > 0:
+			errors = multierror.Append(errors, fmt.Errorf("%s request '%s' should not be greater than the %s limit '%s'",
+				name, request.String(), name, limit.String()))
+		}
+	}
+	if errors != nil {
+		return corev1.ResourceRequirements{}, errors
+	}
+	return requirements, nil
+}
+
+// WorkspaceResources contains the aggregate resource requirements of a workspace.
+type WorkspaceResources struct {
+	// Sum of the resource limits of all the workspace containers
+	Limits corev1.ResourceList
+
+	// Sum of the resource requests of all the workspace containers
+	Requests corev1.ResourceList
+
+	// Names of the containers that have no memory limit.
+	// When not empty, the memory limit of the workspace is unbounded,
+	// unless a default limit is set on the namespace with a `LimitRange`.
+	UnboundedMemory []string
+
+	// Names of the containers that have no CPU limit.
+	// When not empty, the CPU limit of the workspace is unbounded,
+	// unless a default limit is set on the namespace with a `LimitRange`.
+	UnboundedCpu []string
+}
+
+// ComputeWorkspaceResources returns the aggregate resource requirements of the containers of a workspace.
+//
+// The workspace template content is expected to be flattened, so that the containers
+// contributed by plugins are part of its components.
+// The containers added to the workspace pod by the workspace infrastructure, such as authentication proxies,
+// are provided as `podAdditions`, and are taken into account with their Kubernetes resource requirements.
+// Init containers are taken into account the way Kubernetes does when computing the requirements of a pod.
+func ComputeWorkspaceResources(content *workspaces.DevWorkspaceTemplateSpecContent, podAdditions ...*workspaces.WorkspacePodContributions) (*WorkspaceResources, error) {
+	var errors *multierror.Error
+	result := &WorkspaceResources{
+		Limits:   corev1.ResourceList{},
+		Requests: corev1.ResourceList{},
+	}
+
+	for _, component := range content.Components {
+		if component.Container == nil {
+			continue
+		}
+		requirements, err := ContainerResources(component.Container.Container)
+		if err != nil {
+			errors = multierror.Append(errors, fmt.Errorf("invalid resources in container component '%s': %v", component.Name, err))
+			continue
+		}
+		result.add(component.Name, requirements)
+	}
+
+	initRequirements := []corev1.ResourceRequirements{}
+	for _, additions := range podAdditions {
+		if additions == nil {
+			continue
+		}
+		for _, container := range additions.Containers {
+			result.add(container.Name, withDefaultRequests(container.Resources))
+		}
+		for _, container := range additions.InitContainers {
+			initRequirements = append(initRequirements, withDefaultRequests(container.Resources))
+		}
+	}
+
+	// As in Kubernetes, init containers run one after the other before the other containers start,
+	// so they only increase the workspace requirements when they need more than all the other containers.
+	for _, requirements := range initRequirements {
+		maxQuantities(result.Limits, requirements.Limits)
+		maxQuantities(result.Requests, requirements.Requests)
+	}
+
+	if errors != nil {
+		return nil, errors
+	}
+	return result, nil
+}
+
+func (r *WorkspaceResources) add(containerName string, requirements corev1.ResourceRequirements) {
+	for name, quantity := range requirements.Limits {
+		addQuantity(r.Limits, name, quantity)
+	}
+	for name, quantity := range requirements.Requests {
+		addQuantity(r.Requests, name, quantity)
+	}
+	if _, hasLimit := requirements.Limits[corev1.ResourceMemory]; !hasLimit {
+		r.UnboundedMemory = append(r.UnboundedMemory, containerName)
+	}
+	if _, hasLimit := requirements.Limits[corev1.ResourceCPU]; !hasLimit {
+		r.UnboundedCpu = append(r.UnboundedCpu, containerName)
+	}
+}
+
+func addQuantity(list corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) {
+	total, exists := list[name]
+	if !exists {
+		list[name] = quantity.DeepCopy()
+		return
+	}
+	total.Add(quantity)
+	list[name] = total
+}
+
+// withDefaultRequests returns a copy of the requirements where omitted requests default to the corresponding limits.
+func withDefaultRequests(requirements corev1.ResourceRequirements) corev1.ResourceRequirements {
+	result := *requirements.DeepCopy()
+	for name, limit := range result.Limits {
+		if _, hasRequest := result.Requests[name]; !hasRequest {
+			if result.Requests == nil {
+				result.Requests = corev1.ResourceList{}
+			}
+			result.Requests[name] = limit.DeepCopy()
+		}
+	}
+	return result
+}
+
+func maxQuantities(list corev1.ResourceList, other corev1.ResourceList) {
+	for name, quantity := range other {
+		if current, exists := list[name]; !exists || quantity.Cmp(current) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
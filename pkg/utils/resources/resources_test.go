@@ -0,0 +1,97 @@
+package resources
+
+import (
+	"io/ioutil"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+func readTemplate(t *testing.T) *workspaces.DevWorkspaceTemplateSpecContent {
+	content, err := ioutil.ReadFile("test-fixtures/workspace-template.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &workspaces.DevWorkspaceTemplateSpecContent{}
+	if err := yaml.Unmarshal(content, template); err != nil {
+		t.Fatal(err)
+	}
+	return template
+}
+
+func assertQuantity(t *testing.T, expected string, list corev1.ResourceList, name corev1.ResourceName) {
+	actual, exists := list[name]
+	if assert.True(t, exists, "missing %s quantity", name) {
+		expectedQuantity := resource.MustParse(expected)
+		assert.Equal(t, 0, expectedQuantity.Cmp(actual), "expected %s %s, got %s", name, expected, actual.String())
+	}
+}
+
+func TestContainerResourcesDefaultsRequestsToLimits(t *testing.T) {
+	requirements, err := ContainerResources(workspaces.Container{
+		MemoryLimit: "1Gi",
+		CpuLimit:    "1",
+		CpuRequest:  "200m",
+	})
+	assert.NoError(t, err)
+	assertQuantity(t, "1Gi", requirements.Requests, corev1.ResourceMemory)
+	assertQuantity(t, "200m", requirements.Requests, corev1.ResourceCPU)
+}
+
+func TestContainerResourcesRejectsRequestGreaterThanLimit(t *testing.T) {
+	_, err := ContainerResources(workspaces.Container{
+		MemoryLimit:   "256Mi",
+		MemoryRequest: "512Mi",
+	})
+	assert.EqualError(t, err, "1 error occurred:\n\t* memory request '512Mi' should not be greater than the memory limit '256Mi'\n\n")
+}
+
+func TestComputeWorkspaceResources(t *testing.T) {
+	podAdditions := &workspaces.WorkspacePodContributions{
+		Containers: []corev1.Container{
+			{
+				Name: "oauth-proxy-3100",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+					},
+				},
+			},
+		},
+		InitContainers: []corev1.Container{
+			{
+				Name: "project-clone",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+				},
+			},
+		},
+	}
+
+	result, err := ComputeWorkspaceResources(readTemplate(t), podAdditions)
+	assert.NoError(t, err)
+
+	assertQuantity(t, "4Gi", result.Limits, corev1.ResourceMemory)
+	assertQuantity(t, "2600m", result.Limits, corev1.ResourceCPU)
+	assertQuantity(t, "4Gi", result.Requests, corev1.ResourceMemory)
+	assertQuantity(t, "1600m", result.Requests, corev1.ResourceCPU)
+	assert.Equal(t, []string{"node"}, result.UnboundedMemory)
+	assert.Equal(t, []string{"node"}, result.UnboundedCpu)
+}
+
+func TestComputeWorkspaceResourcesWithoutInitContainers(t *testing.T) {
+	result, err := ComputeWorkspaceResources(readTemplate(t))
+	assert.NoError(t, err)
+
+	assertQuantity(t, "1536Mi", result.Limits, corev1.ResourceMemory)
+	assertQuantity(t, "1280Mi", result.Requests, corev1.ResourceMemory)
+	assertQuantity(t, "2500m", result.Limits, corev1.ResourceCPU)
+	assertQuantity(t, "1500m", result.Requests, corev1.ResourceCPU)
+}
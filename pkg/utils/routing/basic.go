@@ -0,0 +1,90 @@
+package routing
+
+import (
+	"fmt"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// BasicSolver is the `Solver` of the `basic` routing class.
+//
+// It exposes the endpoints of each container component through a Kubernetes `Service`,
+// and public endpoints through an `Ingress` per target port, whose host is built from the configured domain.
+// Secure public endpoints are exposed with TLS.
+type BasicSolver struct {
+	Config Config
+}
+
+// Solve returns the routing objects of the `basic` routing class.
+func (s *BasicSolver) Solve(workspace *workspaces.DevWorkspace) (*Routing, error) {
+	ports, err := collectExposedPorts(workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	routing := &Routing{
+		Services: buildServices(workspace, ports, nil),
+	}
+	for _, port := range ports {
+		host := ""
+		if port.isPublic() {
+			if err := ensureWebEndpoints(port); err != nil {
+				return nil, err
+			}
+			if s.Config.Domain == "" {
+				return nil, fmt.Errorf("a domain is required to expose public endpoints with the '%s' routing class", BasicRoutingClass)
+			}
+			host = publicHost(port, s.Config.Domain)
+			routing.Ingresses = append(routing.Ingresses, s.buildIngress(workspace, port, host))
+		}
+		routing.ExposedEndpoints = append(routing.ExposedEndpoints, exposeEndpoints(workspace, port, host)...)
+	}
+	return routing, nil
+}
+
+func (s *BasicSolver) buildIngress(workspace *workspaces.DevWorkspace, port exposedPort, host string) networkingv1beta1.Ingress {
+	ingress := networkingv1beta1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1beta1",
+			Kind:       "Ingress",
+		},
+		ObjectMeta: objectMeta(workspace, fmt.Sprintf("%s-%d", port.serviceName, port.port)),
+		Spec: networkingv1beta1.IngressSpec{
+			Rules: []networkingv1beta1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: networkingv1beta1.IngressRuleValue{
+						HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+							Paths: []networkingv1beta1.HTTPIngressPath{
+								{
+									Path: "/",
+									Backend: networkingv1beta1.IngressBackend{
+										ServiceName: port.serviceName,
+										ServicePort: intstr.FromString(port.portName),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if s.Config.IngressClass != "" {
+		ingress.Annotations = map[string]string{
+			"kubernetes.io/ingress.class": s.Config.IngressClass,
+		}
+	}
+	if port.isSecure() {
+		ingress.Spec.TLS = []networkingv1beta1.IngressTLS{
+			{
+				Hosts:      []string{host},
+				SecretName: s.Config.TLSSecretName,
+			},
+		}
+	}
+	return ingress
+}
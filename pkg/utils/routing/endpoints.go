@@ -0,0 +1,265 @@
+package routing
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/validation"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Config contains the configuration of the built-in routing classes
+type Config struct {
+	// Domain used to build the host of public endpoints,
+	// as `<workspace id>-<component name>-<target port>.<domain>`.
+	// Required by the `basic` routing class to expose public endpoints.
+	// When empty with the `openshift-auth` routing class, the host is generated by the OpenShift router.
+	Domain string
+
+	// Name of the secret that contains the TLS certificate of the ingresses
+	// that expose secure endpoints, with the `basic` routing class.
+	// When empty, the default certificate of the ingress controller is used.
+	TLSSecretName string
+
+	// Class of the ingresses created by the `basic` routing class.
+	IngressClass string
+
+	// Image of the OpenShift OAuth proxy that protects secure endpoints,
+	// with the `openshift-auth` routing class.
+	AuthProxyImage string
+
+	// First port used by the OpenShift OAuth proxies.
+	// Each secure endpoint port is proxied on its own port, starting from this one.
+	AuthProxyBasePort int
+
+	// Name of the service account used by the OpenShift OAuth proxies
+	// to authenticate users. Defaults to `<workspace id>-sa`.
+	AuthProxyServiceAccountName string
+
+	// Name of the secret that contains the cookie secret of the OpenShift OAuth proxies,
+	// in the `cookie-secret` key. Defaults to `<workspace id>-oauth-proxy`.
+	AuthProxyCookieSecretName string
+}
+
+// exposedPort groups the endpoints of a component that target the same port
+type exposedPort struct {
+	component    string
+	dedicatedPod bool
+	serviceName  string
+	portName     string
+	port         int
+	endpoints    []workspaces.Endpoint
+}
+
+func (p exposedPort) isPublic() bool {
+	for _, endpoint := range p.endpoints {
+		if validation.EndpointExposure(endpoint) == workspaces.PublicEndpointExposure {
+			return true
+		}
+	}
+	return false
+}
+
+func (p exposedPort) isSecure() bool {
+	for _, endpoint := range p.endpoints {
+		if validation.IsSecureEndpoint(endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// servesTLS returns true if an endpoint of the port uses the `https` or `wss` protocol,
+// in which case the container itself serves TLS on the port.
+func (p exposedPort) servesTLS() bool {
+	for _, endpoint := range p.endpoints {
+		switch validation.EndpointProtocol(endpoint) {
+		case workspaces.HTTPSEndpointProtocol, workspaces.WSSEndpointProtocol:
+			return true
+		}
+	}
+	return false
+}
+
+func (p exposedPort) transportProtocol() corev1.Protocol {
+	for _, endpoint := range p.endpoints {
+		if validation.EndpointProtocol(endpoint) == workspaces.UDPEndpointProtocol {
+			return corev1.ProtocolUDP
+		}
+	}
+	return corev1.ProtocolTCP
+}
+
+func workspaceId(workspace *workspaces.DevWorkspace) string {
+	if workspace.Status.WorkspaceId != "" {
+		return workspace.Status.WorkspaceId
+	}
+	return workspace.Name
+}
+
+// urlScheme returns the scheme of the endpoint url, promoting `http` and `ws` to `https` and `wss`
+// when the endpoint is secure.
+// `grpc` and `http2` endpoints are reached with the `http` or `https` scheme.
+func urlScheme(endpoint workspaces.Endpoint) string {
+	p := validation.EndpointProtocol(endpoint)
+	switch p {
+	case workspaces.GRPCEndpointProtocol, workspaces.HTTP2EndpointProtocol:
+		p = workspaces.HTTPEndpointProtocol
+	}
+	if validation.IsSecureEndpoint(endpoint) {
+		switch p {
+		case workspaces.HTTPEndpointProtocol:
+			return string(workspaces.HTTPSEndpointProtocol)
+		case workspaces.WSEndpointProtocol:
+			return string(workspaces.WSSEndpointProtocol)
+		}
+	}
+	return string(p)
+}
+
+func urlPath(endpoint workspaces.Endpoint) string {
+	if endpoint.Path == "" || strings.HasPrefix(endpoint.Path, "/") {
+		return endpoint.Path
+	}
+	return "/" + endpoint.Path
+}
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// sanitizeName turns a devfile element name into a valid Kubernetes object name
+func sanitizeName(name string) string {
+	return strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// collectExposedPorts returns the ports of container component endpoints
+// that should be exposed outside of the workspace pod, in the order of the components and endpoints.
+func collectExposedPorts(workspace *workspaces.DevWorkspace) ([]exposedPort, error) {
+	id := sanitizeName(workspaceId(workspace))
+	ports := []exposedPort{}
+	for _, component := range workspace.Spec.Template.Components {
+		if component.Container == nil {
+			continue
+		}
+		componentPorts := map[int]int{}
+		for _, endpoint := range component.Container.Endpoints {
+			if validation.EndpointExposure(endpoint) == workspaces.NoneEndpointExposure {
+				continue
+			}
+			if endpoint.TargetPort <= 0 {
+				return nil, fmt.Errorf("endpoint '%s' of component '%s' should have a target port", endpoint.Name, component.Name)
+			}
+			if index, exists := componentPorts[endpoint.TargetPort]; exists {
+				ports[index].endpoints = append(ports[index].endpoints, endpoint)
+				continue
+			}
+			componentPorts[endpoint.TargetPort] = len(ports)
+			ports = append(ports, exposedPort{
+				component:    component.Name,
+				dedicatedPod: component.Container.DedicatedPod,
+				serviceName:  id + "-" + sanitizeName(component.Name),
+				portName:     fmt.Sprintf("%d-%s", endpoint.TargetPort, strings.ToLower(string(validation.EndpointProtocol(endpoint)))),
+				port:         endpoint.TargetPort,
+				endpoints:    []workspaces.Endpoint{endpoint},
+			})
+		}
+	}
+	return ports, nil
+}
+
+func objectMeta(workspace *workspaces.DevWorkspace, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: workspace.Namespace,
+		Labels: map[string]string{
+			WorkspaceIdLabel: workspaceId(workspace),
+		},
+	}
+}
+
+// buildServices creates one service per component that has exposed endpoints.
+// `targetPorts` allows overriding the service target port of some endpoint ports,
+// for example to route the traffic through a proxy.
+func buildServices(workspace *workspaces.DevWorkspace, ports []exposedPort, targetPorts map[string]int) []corev1.Service {
+	services := []corev1.Service{}
+	serviceIndexes := map[string]int{}
+	for _, port := range ports {
+		index, exists := serviceIndexes[port.serviceName]
+		if !exists {
+			selector := map[string]string{
+				WorkspaceIdLabel: workspaceId(workspace),
+			}
+			if port.dedicatedPod {
+				selector[ComponentLabel] = sanitizeName(port.component)
+			}
+			index = len(services)
+			serviceIndexes[port.serviceName] = index
+			services = append(services, corev1.Service{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "Service",
+				},
+				ObjectMeta: objectMeta(workspace, port.serviceName),
+				Spec: corev1.ServiceSpec{
+					Type:     corev1.ServiceTypeClusterIP,
+					Selector: selector,
+				},
+			})
+		}
+		targetPort := port.port
+		if overridden, isOverridden := targetPorts[port.serviceName+"/"+port.portName]; isOverridden {
+			targetPort = overridden
+		}
+		services[index].Spec.Ports = append(services[index].Spec.Ports, corev1.ServicePort{
+			Name:       port.portName,
+			Protocol:   port.transportProtocol(),
+			Port:       int32(port.port),
+			TargetPort: intstr.FromInt(targetPort),
+		})
+	}
+	return services
+}
+
+// exposeEndpoints returns the exposed endpoints of a port.
+// Public endpoints are reachable at the given public `host`, and internal endpoints at the service address.
+func exposeEndpoints(workspace *workspaces.DevWorkspace, port exposedPort, host string) []ExposedEndpoint {
+	exposed := []ExposedEndpoint{}
+	for _, endpoint := range port.endpoints {
+		var url string
+		if validation.EndpointExposure(endpoint) == workspaces.PublicEndpointExposure {
+			if host != "" {
+				url = fmt.Sprintf("%s://%s%s", urlScheme(endpoint), host, urlPath(endpoint))
+			}
+		} else {
+			url = fmt.Sprintf("%s://%s.%s.svc:%d%s", urlScheme(endpoint), port.serviceName, workspace.Namespace, port.port, urlPath(endpoint))
+		}
+		exposed = append(exposed, ExposedEndpoint{
+			Component:  port.component,
+			Name:       endpoint.Name,
+			Exposure:   validation.EndpointExposure(endpoint),
+			Url:        url,
+			Attributes: endpoint.Attributes,
+		})
+	}
+	return exposed
+}
+
+func publicHost(port exposedPort, domain string) string {
+	if domain == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d.%s", port.serviceName, port.port, domain)
+}
+
+func ensureWebEndpoints(port exposedPort) error {
+	for _, endpoint := range port.endpoints {
+		if validation.EndpointExposure(endpoint) == workspaces.PublicEndpointExposure && !validation.IsApplicationProtocol(validation.EndpointProtocol(endpoint)) {
+			return fmt.Errorf("endpoint '%s' of component '%s' cannot be exposed publicly with the '%s' protocol",
+				endpoint.Name, port.component, validation.EndpointProtocol(endpoint))
+		}
+	}
+	return nil
+}
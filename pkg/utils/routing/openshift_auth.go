@@ -0,0 +1,164 @@
+package routing
+
+import (
+	"fmt"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	defaultAuthProxyImage    = "openshift/oauth-proxy:latest"
+	defaultAuthProxyBasePort = 4400
+
+	authProxyCookieSecretVolume = "oauth-proxy-cookie-secret"
+	authProxyCookieSecretPath   = "/etc/oauth-proxy"
+)
+
+// OpenShiftAuthSolver is the `Solver` of the `openshift-auth` routing class.
+//
+// It exposes the endpoints of each container component through a Kubernetes `Service`,
+// and public endpoints through an OpenShift `Route` per target port.
+//
+// Secure endpoints are only reachable through an OpenShift OAuth proxy, added as a sidecar
+// container of the main workspace pod, so that only authenticated users can access them.
+// Routes of secure endpoints use edge TLS termination.
+// Secure endpoints of components that run in a dedicated pod are not supported.
+type OpenShiftAuthSolver struct {
+	Config Config
+}
+
+// Solve returns the routing objects of the `openshift-auth` routing class.
+func (s *OpenShiftAuthSolver) Solve(workspace *workspaces.DevWorkspace) (*Routing, error) {
+	ports, err := collectExposedPorts(workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	routing := &Routing{}
+	proxyPorts := map[string]int{}
+	nextProxyPort := s.Config.AuthProxyBasePort
+	if nextProxyPort == 0 {
+		nextProxyPort = defaultAuthProxyBasePort
+	}
+	for _, port := range ports {
+		if port.isSecure() {
+			if port.dedicatedPod {
+				return nil, fmt.Errorf("secure endpoints of component '%s' cannot be protected by the '%s' routing class, since the component runs in a dedicated pod",
+					port.component, OpenShiftAuthRoutingClass)
+			}
+			proxyPorts[port.serviceName+"/"+port.portName] = nextProxyPort
+			s.addAuthProxy(workspace, routing, port, nextProxyPort)
+			nextProxyPort++
+		}
+
+		host := ""
+		if port.isPublic() {
+			if err := ensureWebEndpoints(port); err != nil {
+				return nil, err
+			}
+			host = publicHost(port, s.Config.Domain)
+			routing.Routes = append(routing.Routes, s.buildRoute(workspace, port, host))
+		}
+		routing.ExposedEndpoints = append(routing.ExposedEndpoints, exposeEndpoints(workspace, port, host)...)
+	}
+	routing.Services = buildServices(workspace, ports, proxyPorts)
+	return routing, nil
+}
+
+func (s *OpenShiftAuthSolver) buildRoute(workspace *workspaces.DevWorkspace, port exposedPort, host string) unstructured.Unstructured {
+	route := unstructured.Unstructured{}
+	route.SetAPIVersion("route.openshift.io/v1")
+	route.SetKind("Route")
+	meta := objectMeta(workspace, fmt.Sprintf("%s-%d", port.serviceName, port.port))
+	route.SetName(meta.Name)
+	route.SetNamespace(meta.Namespace)
+	route.SetLabels(meta.Labels)
+
+	spec := map[string]interface{}{
+		"to": map[string]interface{}{
+			"kind": "Service",
+			"name": port.serviceName,
+		},
+		"port": map[string]interface{}{
+			"targetPort": port.portName,
+		},
+	}
+	if host != "" {
+		spec["host"] = host
+	}
+	if port.isSecure() {
+		spec["tls"] = map[string]interface{}{
+			"termination":                   "edge",
+			"insecureEdgeTerminationPolicy": "Redirect",
+		}
+	}
+	route.Object["spec"] = spec
+	return route
+}
+
+func (s *OpenShiftAuthSolver) addAuthProxy(workspace *workspaces.DevWorkspace, routing *Routing, port exposedPort, proxyPort int) {
+	id := sanitizeName(workspaceId(workspace))
+	if routing.PodAdditions == nil {
+		cookieSecretName := s.Config.AuthProxyCookieSecretName
+		if cookieSecretName == "" {
+			cookieSecretName = id + "-oauth-proxy"
+		}
+		routing.PodAdditions = &workspaces.WorkspacePodContributions{
+			Volumes: []corev1.Volume{
+				{
+					Name: authProxyCookieSecretVolume,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: cookieSecretName,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	image := s.Config.AuthProxyImage
+	if image == "" {
+		image = defaultAuthProxyImage
+	}
+	// The proxy reaches the container with TLS when the container serves TLS on the port,
+	// rather than only being secured by the proxy and the edge termination of the route
+	upstreamScheme := "http"
+	if port.servesTLS() {
+		upstreamScheme = "https"
+	}
+	serviceAccount := s.Config.AuthProxyServiceAccountName
+	if serviceAccount == "" {
+		serviceAccount = id + "-sa"
+	}
+	routing.PodAdditions.Containers = append(routing.PodAdditions.Containers, corev1.Container{
+		Name:  fmt.Sprintf("oauth-proxy-%d", port.port),
+		Image: image,
+		Args: []string{
+			"--provider=openshift",
+			fmt.Sprintf("--http-address=0.0.0.0:%d", proxyPort),
+			"--https-address=",
+			fmt.Sprintf("--upstream=%s://127.0.0.1:%d", upstreamScheme, port.port),
+			"--openshift-service-account=" + serviceAccount,
+			"--cookie-secret-file=" + authProxyCookieSecretPath + "/cookie-secret",
+			"--pass-access-token",
+			"--skip-provider-button",
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          fmt.Sprintf("proxy-%d", port.port),
+				ContainerPort: int32(proxyPort),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      authProxyCookieSecretVolume,
+				MountPath: authProxyCookieSecretPath,
+				ReadOnly:  true,
+			},
+		},
+	})
+}
@@ -0,0 +1,208 @@
+package routing
+
+import (
+	"fmt"
+	"sort"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/conditions"
+	"github.com/devfile/api/pkg/utils/validation"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// BasicRoutingClass is the routing class that exposes endpoints through Kubernetes `Service`s and `Ingress`es.
+	// It is used when the workspace doesn't specify any routing class.
+	BasicRoutingClass = "basic"
+
+	// OpenShiftAuthRoutingClass is the routing class that exposes endpoints through OpenShift `Route`s,
+	// and protects secure endpoints with an OpenShift OAuth proxy.
+	OpenShiftAuthRoutingClass = "openshift-auth"
+)
+
+const (
+	// WorkspaceIdLabel is the label set on routing objects, and expected on workspace pods,
+	// to identify the workspace they belong to.
+	WorkspaceIdLabel = "workspace.devfile.io/workspace-id"
+
+	// ComponentLabel is the label expected on the pods of components that run in a dedicated pod,
+	// to identify the component they run.
+	ComponentLabel = "workspace.devfile.io/component"
+
+	// EndpointTypeAttribute is the endpoint attribute that describes the type of the endpoint.
+	EndpointTypeAttribute = "type"
+
+	// IdeEndpointType is the value of the `type` endpoint attribute
+	// that marks the endpoint at which the workspace editor can be joined.
+	IdeEndpointType = "ide"
+)
+
+// Routing contains the objects required to expose the endpoints of a workspace,
+// as computed by the `Solver` of a routing class.
+type Routing struct {
+	// Kubernetes services that expose endpoints inside the cluster
+	Services []corev1.Service `json:"services,omitempty"`
+
+	// Kubernetes ingresses that expose public endpoints
+	Ingresses []networkingv1beta1.Ingress `json:"ingresses,omitempty"`
+
+	// OpenShift routes that expose public endpoints
+	Routes []unstructured.Unstructured `json:"routes,omitempty"`
+
+	// Additional elements, such as authentication proxies,
+	// that should be added to the main workspace pod
+	PodAdditions *workspaces.WorkspacePodContributions `json:"podAdditions,omitempty"`
+
+	// Endpoints exposed outside of the main workspace pod, with the URL at which they can be reached
+	ExposedEndpoints []ExposedEndpoint `json:"exposedEndpoints,omitempty"`
+}
+
+// ExposedEndpoint is an endpoint exposed outside of the main workspace pod
+type ExposedEndpoint struct {
+	// Name of the component that declares the endpoint
+	Component string `json:"component"`
+
+	// Name of the endpoint
+	Name string `json:"name"`
+
+	// Exposure of the endpoint
+	Exposure workspaces.EndpointExposure `json:"exposure"`
+
+	// URL at which the endpoint can be reached.
+	// It is empty for public endpoints whose host is generated by the cluster,
+	// and thus unknown until the routing objects are created.
+	Url string `json:"url,omitempty"`
+
+	// Attributes of the endpoint
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Solver computes the routing objects of a given routing class.
+type Solver interface {
+	// Solve returns the objects that expose the endpoints of the workspace.
+	// The workspace template is expected to be flattened (without any parent or plugin).
+	Solve(workspace *workspaces.DevWorkspace) (*Routing, error)
+}
+
+// SolverFunc allows using an ordinary function as a `Solver`.
+type SolverFunc func(workspace *workspaces.DevWorkspace) (*Routing, error)
+
+// Solve calls f(workspace).
+func (f SolverFunc) Solve(workspace *workspaces.DevWorkspace) (*Routing, error) {
+	return f(workspace)
+}
+
+// Registry contains the `Solver`s of the available routing classes.
+type Registry struct {
+	solvers map[string]Solver
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		solvers: map[string]Solver{},
+	}
+}
+
+// NewDefaultRegistry returns a registry that contains the built-in `basic` and `openshift-auth`
+// routing classes, configured with the given configuration.
+func NewDefaultRegistry(config Config) *Registry {
+	registry := NewRegistry()
+	registry.solvers[BasicRoutingClass] = &BasicSolver{Config: config}
+	registry.solvers[OpenShiftAuthRoutingClass] = &OpenShiftAuthSolver{Config: config}
+	return registry
+}
+
+// Register adds the solver of a routing class to the registry.
+// It is not allowed to register the same routing class twice.
+func (r *Registry) Register(routingClass string, solver Solver) error {
+	if routingClass == "" {
+		return fmt.Errorf("routing class should not be empty")
+	}
+	if _, exists := r.solvers[routingClass]; exists {
+		return fmt.Errorf("routing class '%s' is already registered", routingClass)
+	}
+	r.solvers[routingClass] = solver
+	return nil
+}
+
+// Solver returns the solver of the given routing class.
+// An empty routing class is resolved to the `basic` routing class.
+func (r *Registry) Solver(routingClass string) (Solver, error) {
+	if routingClass == "" {
+		routingClass = BasicRoutingClass
+	}
+	solver, exists := r.solvers[routingClass]
+	if !exists {
+		return nil, fmt.Errorf("unknown routing class '%s'", routingClass)
+	}
+	return solver, nil
+}
+
+// RoutingClasses returns the sorted list of registered routing classes
+func (r *Registry) RoutingClasses() []string {
+	classes := []string{}
+	for class := range r.solvers {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	return classes
+}
+
+// ProvisionRouting computes the routing objects of the workspace, with the solver of the
+// workspace routing class, and updates the workspace status accordingly:
+//
+// - the `IdeUrl` is set to the url of the public endpoint that has the `type: ide` attribute,
+//
+// - the `RoutingReady` condition is set to `True`, or to `False` with the reason of the failure.
+//
+// Endpoints are validated before the routing objects are computed.
+func (r *Registry) ProvisionRouting(workspace *workspaces.DevWorkspace) (*Routing, error) {
+	solver, err := r.Solver(workspace.Spec.RoutingClass)
+	if err != nil {
+		conditions.SetCondition(&workspace.Status, workspaces.WorkspaceCondition{
+			Type:    workspaces.WorkspaceRoutingReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "UnknownRoutingClass",
+			Message: err.Error(),
+		})
+		return nil, err
+	}
+
+	if err := validation.ValidateEndpoints(workspace.Spec.Template.Components); err != nil {
+		conditions.SetCondition(&workspace.Status, workspaces.WorkspaceCondition{
+			Type:    workspaces.WorkspaceRoutingReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "InvalidEndpoints",
+			Message: err.Error(),
+		})
+		return nil, err
+	}
+
+	routing, err := solver.Solve(workspace)
+	if err != nil {
+		conditions.SetCondition(&workspace.Status, workspaces.WorkspaceCondition{
+			Type:    workspaces.WorkspaceRoutingReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "RoutingFailed",
+			Message: err.Error(),
+		})
+		return nil, err
+	}
+
+	workspace.Status.IdeUrl = ""
+	for _, endpoint := range routing.ExposedEndpoints {
+		if endpoint.Exposure == workspaces.PublicEndpointExposure &&
+			endpoint.Attributes[EndpointTypeAttribute] == IdeEndpointType {
+			workspace.Status.IdeUrl = endpoint.Url
+			break
+		}
+	}
+	conditions.SetCondition(&workspace.Status, workspaces.WorkspaceCondition{
+		Type:   workspaces.WorkspaceRoutingReady,
+		Status: corev1.ConditionTrue,
+	})
+	return routing, nil
+}
@@ -0,0 +1,138 @@
+package routing
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/conditions"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func readWorkspace(t *testing.T, path string) *workspaces.DevWorkspace {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspace := &workspaces.DevWorkspace{}
+	if err := yaml.Unmarshal(content, workspace); err != nil {
+		t.Fatal(err)
+	}
+	return workspace
+}
+
+func routingTest(workspace *workspaces.DevWorkspace, config Config, expected []byte, expectedError string) func(t *testing.T) {
+	return func(t *testing.T) {
+		routing, err := NewDefaultRegistry(config).ProvisionRouting(workspace)
+		if err != nil {
+			assert.Equal(t, strings.TrimSpace(expectedError), strings.TrimSpace(err.Error()), "wrong error")
+			assert.False(t, conditions.IsConditionTrue(&workspace.Status, workspaces.WorkspaceRoutingReady))
+			return
+		}
+		if expectedError != "" {
+			t.Error("Expected error but did not get one")
+			return
+		}
+
+		result, err := yaml.Marshal(routing)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, string(expected), string(result), "The two values should be the same.")
+		assert.True(t, conditions.IsConditionTrue(&workspace.Status, workspaces.WorkspaceRoutingReady))
+	}
+}
+
+// TestRoutingClasses runs the golden tests found in the `test-fixtures` folder.
+// Each test folder contains:
+// - a `workspace.yaml` file with the DevWorkspace to expose,
+// - an optional `config.yaml` file with the configuration of the routing classes,
+// - either a `result.yaml` file with the expected routing objects, or a `result-error.txt` file with the expected error.
+func TestRoutingClasses(t *testing.T) {
+	filepath.Walk("test-fixtures", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+		if info.IsDir() || info.Name() != "workspace.yaml" {
+			return nil
+		}
+		dirPath := filepath.Dir(path)
+		workspace := readWorkspace(t, path)
+
+		config := Config{}
+		if configContent, err := ioutil.ReadFile(filepath.Join(dirPath, "config.yaml")); err == nil {
+			if err := yaml.Unmarshal(configContent, &config); err != nil {
+				t.Error(err)
+				return nil
+			}
+		}
+
+		result := []byte{}
+		resultError := ""
+		if resultErrorBytes, err := ioutil.ReadFile(filepath.Join(dirPath, "result-error.txt")); err == nil {
+			resultError = string(resultErrorBytes)
+		} else {
+			result, err = ioutil.ReadFile(filepath.Join(dirPath, "result.yaml"))
+			if err != nil {
+				t.Error(err)
+				return nil
+			}
+		}
+
+		testName := filepath.Base(filepath.Dir(dirPath)) + "/" + filepath.Base(dirPath)
+		t.Run(testName, routingTest(workspace, config, result, resultError))
+		return nil
+	})
+}
+
+func TestProvisionRoutingUpdatesStatus(t *testing.T) {
+	workspace := readWorkspace(t, "test-fixtures/basic/public-internal-and-none/workspace.yaml")
+
+	_, err := NewDefaultRegistry(Config{Domain: "apps.example.com"}).ProvisionRouting(workspace)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://workspace1234-theia-3100.apps.example.com", workspace.Status.IdeUrl)
+	condition := conditions.GetCondition(&workspace.Status, workspaces.WorkspaceRoutingReady)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	}
+}
+
+func TestUnknownRoutingClass(t *testing.T) {
+	workspace := readWorkspace(t, "test-fixtures/basic/public-internal-and-none/workspace.yaml")
+	workspace.Spec.RoutingClass = "unknown"
+
+	_, err := NewDefaultRegistry(Config{}).ProvisionRouting(workspace)
+	assert.EqualError(t, err, "unknown routing class 'unknown'")
+	condition := conditions.GetCondition(&workspace.Status, workspaces.WorkspaceRoutingReady)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+		assert.Equal(t, "UnknownRoutingClass", condition.Reason)
+	}
+}
+
+func TestRegisterCustomRoutingClass(t *testing.T) {
+	registry := NewDefaultRegistry(Config{})
+	custom := SolverFunc(func(workspace *workspaces.DevWorkspace) (*Routing, error) {
+		return nil, errors.New("custom routing failure")
+	})
+
+	assert.EqualError(t, registry.Register(BasicRoutingClass, custom), "routing class 'basic' is already registered")
+	assert.NoError(t, registry.Register("custom", custom))
+	assert.Equal(t, []string{"basic", "custom", "openshift-auth"}, registry.RoutingClasses())
+
+	workspace := readWorkspace(t, "test-fixtures/basic/public-internal-and-none/workspace.yaml")
+	workspace.Spec.RoutingClass = "custom"
+	_, err := registry.ProvisionRouting(workspace)
+	assert.EqualError(t, err, "custom routing failure")
+	condition := conditions.GetCondition(&workspace.Status, workspaces.WorkspaceRoutingReady)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, "RoutingFailed", condition.Reason)
+	}
+}
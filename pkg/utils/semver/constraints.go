@@ -0,0 +1,269 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+type operator string
+
+const (
+	equal          operator = "="
+	notEqual       operator = "!="
+	greater        operator = ">"
+	greaterOrEqual operator = ">="
+	less           operator = "<"
+	lessOrEqual    operator = "<="
+	// outside matches the versions that are not in the [version, upper) range
+	outside operator = "!range"
+)
+
+// operators sorted so that the longest ones are matched first
+var operators = []operator{notEqual, greaterOrEqual, lessOrEqual, equal, greater, less, "~", "^"}
+
+type comparator struct {
+	operator operator
+	version  Version
+	upper    Version
+}
+
+func (c comparator) check(v Version) bool {
+	switch c.operator {
+	case equal:
+		return v.Compare(c.version) == 0
+	case notEqual:
+		return v.Compare(c.version) != 0
+	case greater:
+		return v.Compare(c.version) > 0
+	case greaterOrEqual:
+		return v.Compare(c.version) >= 0
+	case less:
+		return v.Compare(c.version) < 0
+	case lessOrEqual:
+		return v.Compare(c.version) <= 0
+	case outside:
+		return v.Compare(c.version) < 0 || v.Compare(c.upper) >= 0
+	}
+	return false
+}
+
+// Constraint is a semantic version constraint, such as `2.x`, `^2.1.0` or `>=1.2.0 <2.0.0`.
+//
+// The supported syntax is the one of npm: comparators (`=`, `!=`, `>`, `>=`, `<`, `<=`),
+// tilde (`~1.2.3`) and caret (`^1.2.3`) ranges, hyphen ranges (`1.2 - 2.3.4`)
+// and `x`, `X` or `*` wildcards. Comparators separated by spaces or commas must all be satisfied,
+// and alternatives are separated by `||`.
+//
+// Pre-release versions only satisfy a constraint when one of its comparators
+// refers to a pre-release of the same major, minor and patch numbers.
+type Constraint struct {
+	text   string
+	groups [][]comparator
+}
+
+// ParseConstraint parses a semantic version constraint.
+func ParseConstraint(text string) (*Constraint, error) {
+	constraint := &Constraint{text: text}
+	for _, alternative := range strings.Split(text, "||") {
+		group, err := parseGroup(alternative)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint '%s': %v", text, err)
+		}
+		constraint.groups = append(constraint.groups, group)
+	}
+	return constraint, nil
+}
+
+func parseGroup(text string) ([]comparator, error) {
+	var tokens []string
+	pendingOperator := ""
+	for _, field := range strings.Fields(strings.ReplaceAll(text, ",", " ")) {
+		if isOperator(field) {
+			pendingOperator += field
+			continue
+		}
+		tokens = append(tokens, pendingOperator+field)
+		pendingOperator = ""
+	}
+	if pendingOperator != "" {
+		return nil, fmt.Errorf("operator '%s' is not followed by a version", pendingOperator)
+	}
+
+	group := []comparator{}
+	for i := 0; i < len(tokens); i++ {
+		if i+2 < len(tokens) && tokens[i+1] == "-" {
+			comparators, err := parseHyphenRange(tokens[i], tokens[i+2])
+			if err != nil {
+				return nil, err
+			}
+			group = append(group, comparators...)
+			i += 2
+			continue
+		}
+		comparators, err := parseComparator(tokens[i])
+		if err != nil {
+			return nil, err
+		}
+		group = append(group, comparators...)
+	}
+	return group, nil
+}
+
+func isOperator(text string) bool {
+	for _, op := range operators {
+		if text == string(op) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseHyphenRange(from, to string) ([]comparator, error) {
+	lower, _, err := parsePartial(from)
+	if err != nil {
+		return nil, err
+	}
+	upper, wildcards, err := parsePartial(to)
+	if err != nil {
+		return nil, err
+	}
+	comparators := []comparator{{operator: greaterOrEqual, version: *lower}}
+	switch {
+	case wildcards == 0:
+		comparators = append(comparators, comparator{operator: lessOrEqual, version: *upper})
+	case wildcards < 3:
+		comparators = append(comparators, comparator{operator: less, version: nextVersion(*upper, wildcards)})
+	}
+	return comparators, nil
+}
+
+func parseComparator(text string) ([]comparator, error) {
+	op := equal
+	for _, candidate := range operators {
+		if strings.HasPrefix(text, string(candidate)) {
+			op = candidate
+			text = strings.TrimPrefix(text, string(candidate))
+			break
+		}
+	}
+	v, wildcards, err := parsePartial(text)
+	if err != nil {
+		return nil, err
+	}
+	if wildcards == 3 {
+		switch op {
+		case greater, less, notEqual:
+			// no version can be greater than, lower than or different from any version
+			return []comparator{{operator: less, version: Version{}}}, nil
+		}
+		return nil, nil
+	}
+
+	switch op {
+	case equal:
+		if wildcards == 0 {
+			return []comparator{{operator: equal, version: *v}}, nil
+		}
+		return between(*v, nextVersion(*v, wildcards)), nil
+	case notEqual:
+		if wildcards == 0 {
+			return []comparator{{operator: notEqual, version: *v}}, nil
+		}
+		return []comparator{{operator: outside, version: *v, upper: nextVersion(*v, wildcards)}}, nil
+	case greater:
+		if wildcards == 0 {
+			return []comparator{{operator: greater, version: *v}}, nil
+		}
+		return []comparator{{operator: greaterOrEqual, version: nextVersion(*v, wildcards)}}, nil
+	case greaterOrEqual, less:
+		return []comparator{{operator: op, version: *v}}, nil
+	case lessOrEqual:
+		if wildcards == 0 {
+			return []comparator{{operator: lessOrEqual, version: *v}}, nil
+		}
+		return []comparator{{operator: less, version: nextVersion(*v, wildcards)}}, nil
+	case "~":
+		if wildcards == 2 {
+			return between(*v, nextVersion(*v, 2)), nil
+		}
+		return between(*v, nextVersion(*v, 1)), nil
+	case "^":
+		switch {
+		case v.Major > 0 || wildcards == 2:
+			return between(*v, nextVersion(*v, 2)), nil
+		case v.Minor > 0 || wildcards == 1:
+			return between(*v, nextVersion(*v, 1)), nil
+		}
+		return between(*v, Version{Patch: v.Patch + 1}), nil
+	}
+	return nil, fmt.Errorf("unsupported operator '%s'", op)
+}
+
+func between(lower, upper Version) []comparator {
+	return []comparator{
+		{operator: greaterOrEqual, version: lower},
+		{operator: less, version: upper},
+	}
+}
+
+// nextVersion returns the lowest version above all the versions matched by a partial version
+// in which the given number of trailing numbers are wildcards.
+func nextVersion(v Version, wildcards int) Version {
+	if wildcards >= 2 {
+		return Version{Major: v.Major + 1}
+	}
+	return Version{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// Check returns whether the version satisfies the constraint.
+func (c *Constraint) Check(v Version) bool {
+	for _, group := range c.groups {
+		if checkGroup(group, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkGroup(group []comparator, v Version) bool {
+	for _, comparator := range group {
+		if !comparator.check(v) {
+			return false
+		}
+	}
+	if v.Prerelease == "" {
+		return true
+	}
+	for _, comparator := range group {
+		if comparator.version.Prerelease != "" &&
+			comparator.version.Major == v.Major &&
+			comparator.version.Minor == v.Minor &&
+			comparator.version.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the constraint as it was parsed.
+func (c *Constraint) String() string {
+	return c.text
+}
+
+// MaxSatisfying returns the highest of the given versions that satisfies the constraint,
+// and whether one was found. Versions that are not valid semantic versions are ignored.
+func (c *Constraint) MaxSatisfying(versions []string) (string, bool) {
+	var max *Version
+	maxText := ""
+	for _, text := range versions {
+		v, err := Parse(text)
+		if err != nil || !c.Check(*v) {
+			continue
+		}
+		if max == nil || v.Compare(*max) > 0 {
+			max = v
+			maxText = text
+		}
+	}
+	return maxText, max != nil
+}
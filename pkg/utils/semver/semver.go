@@ -0,0 +1,172 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version, as defined in https://semver.org.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Metadata   string
+}
+
+// Parse parses a semantic version. A leading `v` is accepted.
+func Parse(version string) (*Version, error) {
+	v, wildcards, err := parsePartial(version)
+	if err != nil {
+		return nil, err
+	}
+	if wildcards > 0 {
+		return nil, fmt.Errorf("invalid semantic version '%s': major, minor and patch numbers are required", version)
+	}
+	return v, nil
+}
+
+// parsePartial parses a version in which the trailing numbers may be missing or replaced
+// by a `x`, `X` or `*` wildcard. It returns the number of missing numbers.
+func parsePartial(version string) (*Version, int, error) {
+	text := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	v := &Version{}
+	if i := strings.Index(text, "+"); i >= 0 {
+		v.Metadata = text[i+1:]
+		text = text[:i]
+		if !validIdentifiers(v.Metadata, false) {
+			return nil, 0, fmt.Errorf("invalid build metadata in semantic version '%s'", version)
+		}
+	}
+	if i := strings.Index(text, "-"); i >= 0 {
+		v.Prerelease = text[i+1:]
+		text = text[:i]
+		if !validIdentifiers(v.Prerelease, true) {
+			return nil, 0, fmt.Errorf("invalid pre-release in semantic version '%s'", version)
+		}
+	}
+
+	parts := strings.Split(text, ".")
+	if len(parts) > 3 {
+		return nil, 0, fmt.Errorf("invalid semantic version '%s'", version)
+	}
+	numbers := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	wildcards := 3 - len(parts)
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			wildcards = 3 - i
+			break
+		}
+		number, err := parseNumber(part)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid semantic version '%s': %v", version, err)
+		}
+		*numbers[i] = number
+	}
+	if wildcards > 0 && (v.Prerelease != "" || v.Metadata != "") {
+		return nil, 0, fmt.Errorf("invalid semantic version '%s': a partial version cannot have a pre-release or build metadata", version)
+	}
+	return v, wildcards, nil
+}
+
+func parseNumber(text string) (uint64, error) {
+	if text == "" {
+		return 0, fmt.Errorf("empty version number")
+	}
+	if len(text) > 1 && text[0] == '0' {
+		return 0, fmt.Errorf("version number '%s' has a leading zero", text)
+	}
+	return strconv.ParseUint(text, 10, 64)
+}
+
+func validIdentifiers(text string, noLeadingZero bool) bool {
+	for _, identifier := range strings.Split(text, ".") {
+		if identifier == "" {
+			return false
+		}
+		numeric := true
+		for _, c := range identifier {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+		if noLeadingZero && numeric && len(identifier) > 1 && identifier[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the canonical form of the version, without a leading `v`.
+func (v Version) String() string {
+	text := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		text += "-" + v.Prerelease
+	}
+	if v.Metadata != "" {
+		text += "+" + v.Metadata
+	}
+	return text
+}
+
+// Compare returns -1, 0 or 1 when `v` is respectively lower than, equal to or greater than `other`.
+// Build metadata is ignored, as specified by semantic versioning.
+func (v Version) Compare(other Version) int {
+	if c := compareNumbers(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareNumbers(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareNumbers(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePrereleases(v.Prerelease, other.Prerelease)
+}
+
+func compareNumbers(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func comparePrereleases(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	aIdentifiers := strings.Split(a, ".")
+	bIdentifiers := strings.Split(b, ".")
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		aNumber, aErr := strconv.ParseUint(aIdentifiers[i], 10, 64)
+		bNumber, bErr := strconv.ParseUint(bIdentifiers[i], 10, 64)
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareNumbers(aNumber, bNumber)
+		case aErr == nil:
+			c = -1
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(aIdentifiers[i], bIdentifiers[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareNumbers(uint64(len(aIdentifiers)), uint64(len(bIdentifiers)))
+}
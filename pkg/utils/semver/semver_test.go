@@ -0,0 +1,97 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	v, err := Parse("v1.2.3-beta.1+build.5")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "beta.1", Metadata: "build.5"}, v)
+		assert.Equal(t, "1.2.3-beta.1+build.5", v.String())
+	}
+
+	for _, invalid := range []string{"", "1", "1.2", "1.2.x", "01.2.3", "1.2.3.4", "1.2.3-", "1.2.3-01", "a.b.c"} {
+		_, err := Parse(invalid)
+		assert.Error(t, err, "version '%s' should be invalid", invalid)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0"}
+	for i := 0; i < len(ordered)-1; i++ {
+		lower, err := Parse(ordered[i])
+		assert.NoError(t, err)
+		greater, err := Parse(ordered[i+1])
+		assert.NoError(t, err)
+		assert.Equal(t, -1, lower.Compare(*greater), "%s should be lower than %s", ordered[i], ordered[i+1])
+		assert.Equal(t, 1, greater.Compare(*lower), "%s should be greater than %s", ordered[i+1], ordered[i])
+	}
+	withMetadata, _ := Parse("1.0.0+build")
+	assert.Equal(t, 0, withMetadata.Compare(Version{Major: 1}))
+}
+
+func TestConstraints(t *testing.T) {
+	tests := []struct {
+		constraint  string
+		matching    []string
+		notMatching []string
+	}{
+		{"2.x", []string{"2.0.0", "2.9.1"}, []string{"1.9.9", "3.0.0", "2.1.0-beta"}},
+		{"*", []string{"0.0.1", "10.0.0"}, []string{"1.0.0-rc.1"}},
+		{"", []string{"1.0.0"}, nil},
+		{"1.2", []string{"1.2.0", "1.2.9"}, []string{"1.3.0"}},
+		{"=1.2.3", []string{"1.2.3"}, []string{"1.2.4"}},
+		{"!=1.2", []string{"1.1.9", "1.3.0"}, []string{"1.2.0", "1.2.5"}},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.9"}},
+		{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{">= 1.2.0, < 2.0.0", []string{"1.2.0", "1.9.0"}, []string{"2.0.0", "1.1.0"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0", "1.2.2"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"^1.2.3", []string{"1.2.3", "1.9.0"}, []string{"2.0.0", "1.2.2"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"1.2 - 2.3.4", []string{"1.2.0", "2.3.4"}, []string{"2.3.5", "1.1.9"}},
+		{"1.x || >=3.1.0", []string{"1.5.0", "3.1.0"}, []string{"2.0.0", "3.0.0"}},
+		{">=1.0.0-beta <1.0.0", []string{"1.0.0-beta.2"}, []string{"1.0.0", "1.0.1-rc.1"}},
+		{">*", nil, []string{"1.0.0"}},
+	}
+	for _, test := range tests {
+		constraint, err := ParseConstraint(test.constraint)
+		if !assert.NoError(t, err, "constraint '%s' should be valid", test.constraint) {
+			continue
+		}
+		for _, version := range test.matching {
+			v, err := Parse(version)
+			assert.NoError(t, err)
+			assert.True(t, constraint.Check(*v), "version '%s' should satisfy '%s'", version, test.constraint)
+		}
+		for _, version := range test.notMatching {
+			v, err := Parse(version)
+			assert.NoError(t, err)
+			assert.False(t, constraint.Check(*v), "version '%s' should not satisfy '%s'", version, test.constraint)
+		}
+	}
+}
+
+func TestInvalidConstraints(t *testing.T) {
+	for _, invalid := range []string{">=", "1.2.3.4", "~a", "1.x-beta"} {
+		_, err := ParseConstraint(invalid)
+		assert.Error(t, err, "constraint '%s' should be invalid", invalid)
+	}
+}
+
+func TestMaxSatisfying(t *testing.T) {
+	constraint, err := ParseConstraint("2.x")
+	if !assert.NoError(t, err) {
+		return
+	}
+	max, found := constraint.MaxSatisfying([]string{"1.9.0", "2.1.0", "2.10.0", "2.2.0", "3.0.0", "2.11.0-rc.1", "latest"})
+	assert.True(t, found)
+	assert.Equal(t, "2.10.0", max)
+
+	_, found = constraint.MaxSatisfying([]string{"1.0.0", "3.0.0"})
+	assert.False(t, found)
+}
@@ -0,0 +1,137 @@
+package syncing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalDirectorySyncer is a `Syncer` that copies the changed files to local directories,
+// for containers whose `sourceMapping` is backed by a directory of the local machine,
+// such as a bind mount or the projects root of a `LocalExecutor`.
+type LocalDirectorySyncer struct {
+	// Local directory of the projects, where the changed files are read
+	ProjectsRoot string
+
+	// Destination returns the local directory that backs the source mapping of a target container
+	Destination func(target Target) string
+}
+
+// Sync copies the modified files, with their permissions, and removes the deleted files.
+// Deleted files that don't exist in the destination are ignored.
+// The paths of the files are checked as in `PlanSync`, so that the destination directory itself is never deleted.
+// Symbolic links of the destination are not followed outside of the destination directory:
+// files whose directory is a link to the outside are rejected, and modified files replace the links found at their path.
+func (l *LocalDirectorySyncer) Sync(ctx context.Context, target Target) error {
+	destination := l.Destination(target)
+	if destination == "" {
+		return fmt.Errorf("no local directory for container '%s'", target.Component)
+	}
+	for _, files := range [][]string{target.Modified, target.Deleted} {
+		for _, file := range files {
+			if err := checkRelativePath(file); err != nil {
+				return fmt.Errorf("changed file '%s' %v", file, err)
+			}
+		}
+	}
+	for _, file := range target.Modified {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		destinationFile, err := destinationPath(destination, file)
+		if err != nil {
+			return err
+		}
+		if err := copyFile(filepath.Join(l.ProjectsRoot, filepath.FromSlash(file)), destinationFile); err != nil {
+			return err
+		}
+	}
+	for _, file := range target.Deleted {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		destinationFile, err := destinationPath(destination, file)
+		if err != nil {
+			return err
+		}
+		// Symbolic links are removed, not followed
+		if err := os.RemoveAll(destinationFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// destinationPath returns the path of a changed file in the destination directory,
+// after checking that the directories of the path, once their symbolic links are resolved,
+// are still in the destination directory.
+func destinationPath(destination string, file string) (string, error) {
+	path := filepath.Join(destination, filepath.FromSlash(file))
+	root, err := filepath.EvalSymlinks(destination)
+	if os.IsNotExist(err) {
+		// The destination is created by the copy, and contains no link yet
+		return path, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	// The directories that don't exist yet are created by the copy
+	directory := filepath.Dir(path)
+	resolved, err := filepath.EvalSymlinks(directory)
+	for os.IsNotExist(err) && directory != filepath.Clean(destination) {
+		directory = filepath.Dir(directory)
+		resolved, err = filepath.EvalSymlinks(directory)
+	}
+	if err != nil {
+		return "", err
+	}
+	relative, err := filepath.Rel(root, resolved)
+	if err != nil {
+		return "", err
+	}
+	if relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("changed file '%s' is in a symbolic link to '%s', outside of the destination directory", file, resolved)
+	}
+	return path, nil
+}
+
+func copyFile(source string, destination string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+	// The file replaces a symbolic link of the destination, instead of overwriting the file it links to
+	if existing, err := os.Lstat(destination); err == nil && existing.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(destination); err != nil {
+			return err
+		}
+	}
+	if info.IsDir() {
+		return os.MkdirAll(destination, info.Mode().Perm())
+	}
+	if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+		return err
+	}
+
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(destination, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	// The permissions of an existing file are not changed by `OpenFile`
+	return os.Chmod(destination, info.Mode().Perm())
+}
@@ -0,0 +1,176 @@
+// Package syncing plans the synchronization of the local changes of project sources
+// to the containers of a running workspace, and whether the run command must be restarted
+// to pick up the changes.
+package syncing
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/validation"
+	"github.com/hashicorp/go-multierror"
+)
+
+// Changes are the files that changed in the local directory of the projects.
+// The paths are slash-separated and relative to the projects root directory.
+type Changes struct {
+	// Files created or modified
+	Modified []string
+	// Files deleted
+	Deleted []string
+}
+
+// Target is a container component that needs the changed files
+type Target struct {
+	// Name of the container component
+	Component string
+
+	// Path of the container the projects root directory is synchronized to,
+	// from the `sourceMapping` of the container
+	SourceMapping string
+
+	// Files to copy to the container, relative to the projects root directory
+	Modified []string
+
+	// Files to delete from the container, relative to the projects root directory
+	Deleted []string
+}
+
+// Plan is the plan of the synchronization of local changes to a workspace
+type Plan struct {
+	// Containers that need the changed files
+	Targets []Target
+
+	// Whether the run command must be restarted for the changes to be picked up,
+	// because one of its `exec` commands runs in a target container and is not `hotReloadCapable`
+	RestartRunCommand bool
+}
+
+// Syncer synchronizes the changed files to a container.
+type Syncer interface {
+	Sync(ctx context.Context, target Target) error
+}
+
+// SyncerFunc allows using an ordinary function as a `Syncer`.
+type SyncerFunc func(ctx context.Context, target Target) error
+
+// Sync calls f(ctx, target).
+func (f SyncerFunc) Sync(ctx context.Context, target Target) error {
+	return f(ctx, target)
+}
+
+// PlanSync plans the synchronization of local changes to the containers of a flattened workspace template.
+//
+// The containers that mount the project sources need the changed files, at their `sourceMapping` path.
+// When a run command is given, it must be restarted if one of its `exec` commands, or of the `exec` commands
+// of its composite commands, runs in a container that needs the files and is not `hotReloadCapable`.
+// Otherwise, the run command is left alone, since it reloads the changes on its own or doesn't see them.
+func PlanSync(content *workspaces.DevWorkspaceTemplateSpecContent, changes Changes, runCommand string) (*Plan, error) {
+	var errors *multierror.Error
+	for _, files := range [][]string{changes.Modified, changes.Deleted} {
+		for _, file := range files {
+			if err := checkRelativePath(file); err != nil {
+				errors = multierror.Append(errors, fmt.Errorf("changed file '%s' %v", file, err))
+			}
+		}
+	}
+	if err := errors.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	targets := map[string]bool{}
+	if len(changes.Modified) > 0 || len(changes.Deleted) > 0 {
+		for _, component := range content.Components {
+			if component.Container == nil || !component.Container.MountSources {
+				continue
+			}
+			sourceMapping := component.Container.SourceMapping
+			if sourceMapping == "" {
+				sourceMapping = validation.DefaultSourceMapping
+			}
+			targets[component.Name] = true
+			plan.Targets = append(plan.Targets, Target{
+				Component:     component.Name,
+				SourceMapping: sourceMapping,
+				Modified:      changes.Modified,
+				Deleted:       changes.Deleted,
+			})
+		}
+	}
+
+	if runCommand == "" {
+		return plan, nil
+	}
+	commands := map[string]workspaces.Command{}
+	for _, command := range content.Commands {
+		commands[command.Id] = command
+	}
+	execCommands, err := resolveExecCommands(commands, runCommand, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, exec := range execCommands {
+		if targets[exec.Component] && !exec.HotReloadCapable {
+			plan.RestartRunCommand = true
+		}
+	}
+	return plan, nil
+}
+
+// resolveExecCommands returns the `exec` commands run by a command, going through composite commands.
+func resolveExecCommands(commands map[string]workspaces.Command, id string, parents []string) ([]workspaces.ExecCommand, error) {
+	for _, parent := range parents {
+		if parent == id {
+			return nil, fmt.Errorf("composite commands have a cycle: %s -> %s", strings.Join(parents, " -> "), id)
+		}
+	}
+	command, exists := commands[id]
+	if !exists {
+		return nil, fmt.Errorf("command '%s' doesn't exist", id)
+	}
+	switch {
+	case command.Exec != nil:
+		return []workspaces.ExecCommand{*command.Exec}, nil
+	case command.Composite != nil:
+		var execCommands []workspaces.ExecCommand
+		for _, subCommand := range command.Composite.Commands {
+			resolved, err := resolveExecCommands(commands, subCommand, append(parents, id))
+			if err != nil {
+				return nil, err
+			}
+			execCommands = append(execCommands, resolved...)
+		}
+		return execCommands, nil
+	}
+	return nil, nil
+}
+
+func checkRelativePath(file string) error {
+	if path.IsAbs(file) {
+		return fmt.Errorf("should be relative to the projects root directory")
+	}
+	cleaned := path.Clean(file)
+	if cleaned == "." {
+		return fmt.Errorf("should be a file of the projects root directory, not the directory itself")
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return fmt.Errorf("should not climb above the projects root directory")
+	}
+	return nil
+}
+
+// Sync synchronizes the changed files to all the targets of the plan,
+// and returns the errors of all the targets that failed.
+func (p *Plan) Sync(ctx context.Context, syncer Syncer) error {
+	var errors *multierror.Error
+	for _, target := range p.Targets {
+		if err := syncer.Sync(ctx, target); err != nil {
+			errors = multierror.Append(errors, fmt.Errorf("failed synchronizing container '%s': %v", target.Component, err))
+		}
+	}
+	return errors.ErrorOrNil()
+}
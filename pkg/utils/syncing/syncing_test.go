@@ -0,0 +1,270 @@
+package syncing
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+const template = `
+components:
+  - name: web
+    container:
+      image: quay.io/example/web:latest
+      mountSources: true
+  - name: worker
+    container:
+      image: quay.io/example/worker:latest
+      mountSources: true
+      sourceMapping: /src
+  - name: db
+    container:
+      image: quay.io/example/db:latest
+commands:
+  - id: run-web
+    exec:
+      component: web
+      commandLine: npm run dev
+      hotReloadCapable: true
+  - id: run-worker
+    exec:
+      component: worker
+      commandLine: node worker.js
+  - id: run-db
+    exec:
+      component: db
+      commandLine: postgres
+  - id: run-all
+    composite:
+      parallel: true
+      commands: [run-web, run-worker]
+  - id: run-web-and-db
+    composite:
+      commands: [run-web, run-db]
+  - id: cycle
+    composite:
+      commands: [run-web, cycle]
+`
+
+func planSync(t *testing.T, changes Changes, runCommand string) (*Plan, error) {
+	content := &workspaces.DevWorkspaceTemplateSpecContent{}
+	if err := yaml.Unmarshal([]byte(template), content); err != nil {
+		t.Fatal(err)
+	}
+	return PlanSync(content, changes, runCommand)
+}
+
+func TestPlanSync(t *testing.T) {
+	changes := Changes{Modified: []string{"app/index.js"}, Deleted: []string{"app/old.js"}}
+	tests := []struct {
+		name       string
+		runCommand string
+		changes    Changes
+		restart    bool
+		targets    []string
+	}{
+		{
+			name:       "hot reload capable",
+			runCommand: "run-web",
+			changes:    changes,
+			targets:    []string{"web", "worker"},
+		},
+		{
+			name:       "not hot reload capable",
+			runCommand: "run-worker",
+			changes:    changes,
+			restart:    true,
+			targets:    []string{"web", "worker"},
+		},
+		{
+			name:       "composite with a command that is not hot reload capable",
+			runCommand: "run-all",
+			changes:    changes,
+			restart:    true,
+			targets:    []string{"web", "worker"},
+		},
+		{
+			name:       "container without sources",
+			runCommand: "run-web-and-db",
+			changes:    changes,
+			targets:    []string{"web", "worker"},
+		},
+		{
+			name:       "no changes",
+			runCommand: "run-worker",
+		},
+		{
+			name:    "no run command",
+			changes: changes,
+			targets: []string{"web", "worker"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := planSync(t, tt.changes, tt.runCommand)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.restart, plan.RestartRunCommand)
+			var targets []string
+			for _, target := range plan.Targets {
+				targets = append(targets, target.Component)
+				assert.Equal(t, tt.changes.Modified, target.Modified)
+				assert.Equal(t, tt.changes.Deleted, target.Deleted)
+			}
+			assert.Equal(t, tt.targets, targets)
+		})
+	}
+}
+
+func TestPlanSyncSourceMapping(t *testing.T) {
+	plan, err := planSync(t, Changes{Modified: []string{"app/index.js"}}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "/projects", plan.Targets[0].SourceMapping)
+	assert.Equal(t, "/src", plan.Targets[1].SourceMapping)
+}
+
+func TestPlanSyncErrors(t *testing.T) {
+	_, err := planSync(t, Changes{Modified: []string{"/etc/passwd", "app/../../secret"}}, "")
+	assert.EqualError(t, err, "2 errors occurred:\n"+
+		"\t* changed file '/etc/passwd' should be relative to the projects root directory\n"+
+		"\t* changed file 'app/../../secret' should not climb above the projects root directory\n\n")
+
+	_, err = planSync(t, Changes{Deleted: []string{"", ".", "app/.."}}, "")
+	assert.EqualError(t, err, "3 errors occurred:\n"+
+		"\t* changed file '' should be a file of the projects root directory, not the directory itself\n"+
+		"\t* changed file '.' should be a file of the projects root directory, not the directory itself\n"+
+		"\t* changed file 'app/..' should be a file of the projects root directory, not the directory itself\n\n")
+
+	_, err = planSync(t, Changes{Modified: []string{"app/index.js"}}, "unknown")
+	assert.EqualError(t, err, "command 'unknown' doesn't exist")
+
+	_, err = planSync(t, Changes{Modified: []string{"app/index.js"}}, "cycle")
+	assert.EqualError(t, err, "composite commands have a cycle: cycle -> cycle")
+}
+
+func TestLocalDirectorySyncer(t *testing.T) {
+	root, err := ioutil.TempDir("", "syncing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	projects := filepath.Join(root, "projects")
+	writeFile(t, filepath.Join(projects, "app", "index.js"), "console.log('new')", 0644)
+	writeFile(t, filepath.Join(projects, "app", "run.sh"), "#!/bin/sh", 0755)
+	writeFile(t, filepath.Join(root, "web", "app", "index.js"), "console.log('old')", 0644)
+	writeFile(t, filepath.Join(root, "web", "app", "old.js"), "", 0644)
+
+	plan, err := planSync(t, Changes{
+		Modified: []string{"app/index.js", "app/run.sh"},
+		Deleted:  []string{"app/old.js", "app/missing.js"},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	syncer := &LocalDirectorySyncer{
+		ProjectsRoot: projects,
+		Destination: func(target Target) string {
+			return filepath.Join(root, target.Component)
+		},
+	}
+	assert.NoError(t, plan.Sync(context.Background(), syncer))
+
+	for _, component := range []string{"web", "worker"} {
+		content, err := ioutil.ReadFile(filepath.Join(root, component, "app", "index.js"))
+		assert.NoError(t, err)
+		assert.Equal(t, "console.log('new')", string(content))
+		info, err := os.Stat(filepath.Join(root, component, "app", "run.sh"))
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+		_, err = os.Stat(filepath.Join(root, component, "app", "old.js"))
+		assert.True(t, os.IsNotExist(err))
+	}
+
+	err = syncer.Sync(context.Background(), Target{Component: "web", Deleted: []string{""}})
+	assert.EqualError(t, err, "changed file '' should be a file of the projects root directory, not the directory itself")
+	_, err = os.Stat(filepath.Join(root, "web"))
+	assert.NoError(t, err, "the destination should not be deleted")
+
+	plan.Targets[0].Modified = []string{"app/missing.js"}
+	err = plan.Sync(context.Background(), syncer)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed synchronizing container 'web'")
+}
+
+func TestLocalDirectorySyncerSymlinks(t *testing.T) {
+	root, err := ioutil.TempDir("", "syncing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	projects := filepath.Join(root, "projects")
+	destination := filepath.Join(root, "web")
+	outside := filepath.Join(root, "outside")
+	writeFile(t, filepath.Join(projects, "link", "index.js"), "console.log('new')", 0644)
+	writeFile(t, filepath.Join(projects, "link", "new", "index.js"), "console.log('new')", 0644)
+	writeFile(t, filepath.Join(projects, "config.json"), "{}", 0644)
+	writeFile(t, filepath.Join(outside, "index.js"), "console.log('outside')", 0644)
+	writeFile(t, filepath.Join(outside, "config.json"), `{"outside": true}`, 0644)
+	writeFile(t, filepath.Join(destination, "app", "index.js"), "console.log('old')", 0644)
+	for link, target := range map[string]string{
+		"link":        outside,
+		"app-link":    filepath.Join(destination, "app"),
+		"config.json": filepath.Join(outside, "config.json"),
+	} {
+		if err := os.Symlink(target, filepath.Join(destination, link)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	syncer := &LocalDirectorySyncer{
+		ProjectsRoot: projects,
+		Destination: func(target Target) string {
+			return destination
+		},
+	}
+
+	for _, target := range []Target{
+		{Component: "web", Modified: []string{"link/index.js"}},
+		{Component: "web", Modified: []string{"link/new/index.js"}},
+		{Component: "web", Deleted: []string{"link/index.js"}},
+	} {
+		err = syncer.Sync(context.Background(), target)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "outside of the destination directory")
+		}
+	}
+	content, err := ioutil.ReadFile(filepath.Join(outside, "index.js"))
+	assert.NoError(t, err)
+	assert.Equal(t, "console.log('outside')", string(content), "files outside of the destination should not be changed")
+	_, err = os.Stat(filepath.Join(outside, "new"))
+	assert.True(t, os.IsNotExist(err), "directories outside of the destination should not be created")
+
+	// Links inside the destination are followed
+	assert.NoError(t, syncer.Sync(context.Background(), Target{Component: "web", Deleted: []string{"app-link/index.js"}}))
+	_, err = os.Stat(filepath.Join(destination, "app", "index.js"))
+	assert.True(t, os.IsNotExist(err))
+
+	// A link at the path of a modified file is replaced by the file
+	assert.NoError(t, syncer.Sync(context.Background(), Target{Component: "web", Modified: []string{"config.json"}}))
+	content, err = ioutil.ReadFile(filepath.Join(destination, "config.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(content))
+	content, err = ioutil.ReadFile(filepath.Join(outside, "config.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"outside": true}`, string(content), "the file the link pointed to should not be changed")
+}
+
+func writeFile(t *testing.T, file string, content string, mode os.FileMode) {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file, []byte(content), mode); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,333 @@
+// Package tekton generates Tekton pipelines from the build and test commands of devfiles,
+// so that continuous integration can reuse the commands of the development workspaces.
+package tekton
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/environment"
+	"github.com/devfile/api/pkg/utils/images"
+	"github.com/devfile/api/pkg/utils/validation"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SourceWorkspace is the name of the pipeline workspace that contains the project sources
+const SourceWorkspace = "source"
+
+// DefaultBuilderImage is the image that builds the images of image components when no builder image is given
+const DefaultBuilderImage = "gcr.io/kaniko-project/executor:v1.3.0"
+
+// Options are the options of the generation of a Tekton pipeline from a devfile
+type Options struct {
+	// Name of the pipeline, also used as the prefix of the names of the tasks
+	Name string
+
+	// Kaniko image that builds and pushes the images of the image components.
+	//
+	// Defaults to `DefaultBuilderImage`
+	BuilderImage string
+}
+
+// GeneratePipeline generates a Tekton pipeline that runs the default build command of a flattened devfile,
+// and then its default test command. The default command of a group is the command of the group
+// marked with `isDefault`, or the only command of the group.
+//
+// - each `exec` command becomes a task with a single step, that runs the command line in the image
+// of the command container, with the environment variables of the container and the command,
+// and the command working directory,
+//
+// - `composite` commands become pipeline tasks that run one after the other, or in parallel
+// when the composite command is parallel,
+//
+// - volume components mounted by the command containers become pipeline workspaces, as well as
+// the project sources, which are provided by the `source` workspace,
+//
+// - when the container of an `exec` command runs the image of an image component, a task
+// that builds and pushes the image with kaniko runs before the task of the command.
+//
+// Returns an error if the devfile has neither a default build command nor a default test command,
+// or if these commands use other types of commands.
+func GeneratePipeline(content *workspaces.DevWorkspaceTemplateSpecContent, options Options) (*Resources, error) {
+	if options.Name == "" {
+		return nil, fmt.Errorf("a name is required to generate a pipeline")
+	}
+	if options.BuilderImage == "" {
+		options.BuilderImage = DefaultBuilderImage
+	}
+	g := &generator{
+		options:       options,
+		components:    content.Components,
+		commands:      map[string]workspaces.Command{},
+		containers:    map[string]workspaces.Container{},
+		buildTasks:    map[string]string{},
+		usedTaskNames: map[string]bool{},
+		usedVolumes:   map[string]bool{},
+		resources: &Resources{
+			Pipeline: Pipeline{
+				APIVersion: APIVersion,
+				Kind:       "Pipeline",
+				Metadata:   ObjectMeta{Name: options.Name},
+			},
+		},
+	}
+	for _, command := range content.Commands {
+		g.commands[command.Id] = command
+	}
+	for _, component := range content.Components {
+		if component.Container != nil {
+			g.containers[component.Name] = component.Container.Container
+		}
+	}
+	if len(content.Projects) > 0 {
+		cloneDirectory, err := validation.CloneDirectory(content.Projects[0])
+		if err != nil {
+			return nil, err
+		}
+		g.projectSource = cloneDirectory
+	}
+
+	var previous []string
+	hasDefaultCommand := false
+	for _, kind := range []workspaces.CommandGroupKind{workspaces.BuildCommandGroupKind, workspaces.TestCommandGroupKind} {
+		command, err := content.GetDefaultCommand(kind)
+		if _, noCommand := err.(*workspaces.NoCommandInGroupError); noCommand {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		hasDefaultCommand = true
+		if previous, err = g.addCommand(command.Id, previous, nil); err != nil {
+			return nil, err
+		}
+	}
+	if !hasDefaultCommand {
+		return nil, fmt.Errorf("the devfile has neither a default build command nor a default test command")
+	}
+
+	if g.usesSources {
+		g.resources.Pipeline.Spec.Workspaces = append(g.resources.Pipeline.Spec.Workspaces, PipelineWorkspace{Name: SourceWorkspace})
+	}
+	for _, component := range content.Components {
+		if component.Volume != nil && g.usedVolumes[component.Name] {
+			g.resources.Pipeline.Spec.Workspaces = append(g.resources.Pipeline.Spec.Workspaces, PipelineWorkspace{Name: component.Name})
+		}
+	}
+	return g.resources, nil
+}
+
+type generator struct {
+	options       Options
+	components    []workspaces.Component
+	commands      map[string]workspaces.Command
+	containers    map[string]workspaces.Container
+	buildTasks    map[string]string
+	projectSource string
+	usedTaskNames map[string]bool
+	usedVolumes   map[string]bool
+	usesSources   bool
+	resources     *Resources
+}
+
+// addCommand adds the pipeline tasks of a command, that run after the given pipeline tasks,
+// and returns the names of the last pipeline tasks of the command.
+func (g *generator) addCommand(id string, runAfter []string, parents []string) ([]string, error) {
+	for _, parent := range parents {
+		if parent == id {
+			return nil, fmt.Errorf("composite commands have a cycle: %s -> %s", strings.Join(parents, " -> "), id)
+		}
+	}
+	command, exists := g.commands[id]
+	if !exists {
+		if len(parents) > 0 {
+			return nil, fmt.Errorf("composite command '%s' references command '%s', which doesn't exist", parents[len(parents)-1], id)
+		}
+		return nil, fmt.Errorf("command '%s' doesn't exist", id)
+	}
+
+	switch {
+	case command.Exec != nil:
+		name, err := g.addExecCommand(id, command.Exec, runAfter)
+		if err != nil {
+			return nil, err
+		}
+		return []string{name}, nil
+	case command.Composite != nil:
+		parents = append(parents, id)
+		if command.Composite.Parallel {
+			var last []string
+			for _, subCommand := range command.Composite.Commands {
+				subLast, err := g.addCommand(subCommand, runAfter, parents)
+				if err != nil {
+					return nil, err
+				}
+				last = append(last, subLast...)
+			}
+			return last, nil
+		}
+		last := runAfter
+		for _, subCommand := range command.Composite.Commands {
+			var err error
+			if last, err = g.addCommand(subCommand, last, parents); err != nil {
+				return nil, err
+			}
+		}
+		return last, nil
+	}
+	return nil, fmt.Errorf("command '%s' cannot run in a pipeline, since only exec and composite commands are supported", id)
+}
+
+func (g *generator) addExecCommand(id string, exec *workspaces.ExecCommand, runAfter []string) (string, error) {
+	container, exists := g.containers[exec.Component]
+	if !exists {
+		return "", fmt.Errorf("command '%s' runs in component '%s', which is not a container component", id, exec.Component)
+	}
+
+	image, build := images.ContainerImage(g.components, container)
+	if build != nil {
+		runAfter = append(append([]string{}, runAfter...), g.addBuildTask(*build))
+	}
+
+	name := g.taskName(id)
+	task := Task{
+		APIVersion: APIVersion,
+		Kind:       "Task",
+		Metadata:   ObjectMeta{Name: g.options.Name + "-" + name},
+	}
+	pipelineTask := PipelineTask{
+		Name:     name,
+		TaskRef:  TaskRef{Name: task.Metadata.Name},
+		RunAfter: runAfter,
+	}
+	step := Step{
+		Name:   id,
+		Image:  image,
+		Script: exec.CommandLine,
+	}
+
+	variables := map[string]string{}
+	if container.MountSources {
+		g.usesSources = true
+		sourceMapping := container.SourceMapping
+		if sourceMapping == "" {
+			sourceMapping = validation.DefaultSourceMapping
+		}
+		task.Spec.Workspaces = append(task.Spec.Workspaces, TaskWorkspace{Name: SourceWorkspace, MountPath: sourceMapping})
+		pipelineTask.Workspaces = append(pipelineTask.Workspaces, PipelineTaskWorkspace{Name: SourceWorkspace, Workspace: SourceWorkspace})
+		variables["PROJECTS_ROOT"] = sourceMapping
+		variables["PROJECT_SOURCE"] = path.Join(sourceMapping, g.projectSource)
+		step.Env = append(step.Env, corev1.EnvVar{Name: "PROJECTS_ROOT", Value: variables["PROJECTS_ROOT"]})
+		step.Env = append(step.Env, corev1.EnvVar{Name: "PROJECT_SOURCE", Value: variables["PROJECT_SOURCE"]})
+	}
+	for _, mount := range container.VolumeMounts {
+		mountPath := mount.Path
+		if mountPath == "" {
+			mountPath = "/" + mount.Name
+		}
+		g.usedVolumes[mount.Name] = true
+		task.Spec.Workspaces = append(task.Spec.Workspaces, TaskWorkspace{Name: mount.Name, MountPath: mountPath})
+		pipelineTask.Workspaces = append(pipelineTask.Workspaces, PipelineTaskWorkspace{Name: mount.Name, Workspace: mount.Name})
+	}
+
+	var err error
+	if step.Env, err = mergeEnv(step.Env, container.Env); err != nil {
+		return "", fmt.Errorf("container '%s': %v", exec.Component, err)
+	}
+	if step.Env, err = mergeEnv(step.Env, exec.Env); err != nil {
+		return "", fmt.Errorf("command '%s': %v", id, err)
+	}
+	step.WorkingDir = expandVariables(exec.WorkingDir, variables)
+	task.Spec.Steps = []Step{step}
+
+	g.resources.Tasks = append(g.resources.Tasks, task)
+	g.resources.Pipeline.Spec.Tasks = append(g.resources.Pipeline.Spec.Tasks, pipelineTask)
+	return name, nil
+}
+
+// addBuildTask adds the pipeline task that builds the image of an image component, if not already added,
+// and returns its name.
+func (g *generator) addBuildTask(build images.Build) string {
+	if name, added := g.buildTasks[build.Component]; added {
+		return name
+	}
+	name := g.taskName("build-" + build.Component)
+	g.buildTasks[build.Component] = name
+	g.usesSources = true
+
+	buildContext := path.Join(validation.DefaultSourceMapping, g.projectSource, build.BuildContext)
+	args := []string{
+		"--dockerfile=" + path.Join(buildContext, build.Dockerfile),
+		"--context=" + buildContext,
+		"--destination=" + build.ImageName,
+	}
+	for _, arg := range build.Args {
+		args = append(args, "--build-arg="+arg.Name+"="+arg.Value)
+	}
+	task := Task{
+		APIVersion: APIVersion,
+		Kind:       "Task",
+		Metadata:   ObjectMeta{Name: g.options.Name + "-" + name},
+		Spec: TaskSpec{
+			Workspaces: []TaskWorkspace{{Name: SourceWorkspace, MountPath: validation.DefaultSourceMapping}},
+			Steps: []Step{{
+				Name:    "build",
+				Image:   g.options.BuilderImage,
+				Command: []string{"/kaniko/executor"},
+				Args:    args,
+			}},
+		},
+	}
+	g.resources.Tasks = append(g.resources.Tasks, task)
+	g.resources.Pipeline.Spec.Tasks = append(g.resources.Pipeline.Spec.Tasks, PipelineTask{
+		Name:       name,
+		TaskRef:    TaskRef{Name: task.Metadata.Name},
+		Workspaces: []PipelineTaskWorkspace{{Name: SourceWorkspace, Workspace: SourceWorkspace}},
+	})
+	return name
+}
+
+// taskName returns a unique pipeline task name, based on the given name
+func (g *generator) taskName(base string) string {
+	name := base
+	for i := 2; g.usedTaskNames[name]; i++ {
+		name = base + "-" + strconv.Itoa(i)
+	}
+	g.usedTaskNames[name] = true
+	return name
+}
+
+// mergeEnv sets the devfile environment variables, which override the existing ones with the same name.
+// Since a pipeline doesn't run in a workspace, the variables cannot reference the workspace name or id.
+func mergeEnv(env []corev1.EnvVar, variables []workspaces.EnvVar) ([]corev1.EnvVar, error) {
+	converted, err := environment.KubernetesEnv(variables, environment.WorkspaceMetadata{})
+	if err != nil {
+		return nil, err
+	}
+	for _, variable := range converted {
+		overridden := false
+		for i := range env {
+			if env[i].Name == variable.Name {
+				env[i] = variable
+				overridden = true
+			}
+		}
+		if !overridden {
+			env = append(env, variable)
+		}
+	}
+	return env, nil
+}
+
+// expandVariables replaces the `${PROJECTS_ROOT}` and `${PROJECT_SOURCE}` variables of a working directory,
+// since Kubernetes doesn't expand variables in the working directory of containers.
+func expandVariables(value string, variables map[string]string) string {
+	for name, variableValue := range variables {
+		value = strings.ReplaceAll(value, "${"+name+"}", variableValue)
+		value = strings.ReplaceAll(value, "$"+name, variableValue)
+	}
+	return value
+}
@@ -0,0 +1,105 @@
+package tekton
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func readDevfile(t *testing.T) *workspaces.DevWorkspaceTemplateSpecContent {
+	bytes, err := ioutil.ReadFile(filepath.Join("test-fixtures", "devfile.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := &workspaces.DevWorkspaceTemplateSpecContent{}
+	if err := yaml.Unmarshal(bytes, content); err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestGeneratePipeline(t *testing.T) {
+	resources, err := GeneratePipeline(readDevfile(t), Options{Name: "web-app"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	marshaled, err := resources.Marshal()
+	if !assert.NoError(t, err) {
+		return
+	}
+	expected, err := ioutil.ReadFile(filepath.Join("test-fixtures", "pipeline.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(expected), string(marshaled))
+}
+
+func TestGeneratePipelineErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		update        func(content *workspaces.DevWorkspaceTemplateSpecContent)
+		expectedError string
+	}{
+		{
+			name: "No default command",
+			update: func(content *workspaces.DevWorkspaceTemplateSpecContent) {
+				content.Commands = content.Commands[:4]
+			},
+			expectedError: "the devfile has neither a default build command nor a default test command",
+		},
+		{
+			name: "Several default commands",
+			update: func(content *workspaces.DevWorkspaceTemplateSpecContent) {
+				content.Commands[5].Exec.Group.IsDefault = true
+				content.Commands[5].Exec.Group.Kind = workspaces.BuildCommandGroupKind
+			},
+			expectedError: "the 'build' command group has several default commands: 'build', 'test'",
+		},
+		{
+			name: "Unsupported command type",
+			update: func(content *workspaces.DevWorkspaceTemplateSpecContent) {
+				content.Commands[0] = workspaces.Command{
+					Id: "install",
+					CommandUnion: workspaces.CommandUnion{
+						Apply: &workspaces.ApplyCommand{Component: "nodejs"},
+					},
+				}
+			},
+			expectedError: "command 'install' cannot run in a pipeline, since only exec and composite commands are supported",
+		},
+		{
+			name: "Composite cycle",
+			update: func(content *workspaces.DevWorkspaceTemplateSpecContent) {
+				content.Commands[3].Composite.Commands = append(content.Commands[3].Composite.Commands, "build")
+			},
+			expectedError: "composite commands have a cycle: build -> check -> build",
+		},
+		{
+			name: "Workspace metadata",
+			update: func(content *workspaces.DevWorkspaceTemplateSpecContent) {
+				content.Commands[2].Exec.Env = append(content.Commands[2].Exec.Env, workspaces.EnvVar{
+					Name: "WORKSPACE_ID",
+					ValueFrom: &workspaces.EnvVarSource{
+						WorkspaceRef: &workspaces.WorkspaceMetadataSelector{Field: workspaces.IdWorkspaceMetadataField},
+					},
+				})
+			},
+			expectedError: "command 'compile': 1 error occurred:\n\t* environment variable 'WORKSPACE_ID' references the id of the workspace, which is not known",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := readDevfile(t)
+			tt.update(content)
+			_, err := GeneratePipeline(content, Options{Name: "web-app"})
+			if assert.Error(t, err) {
+				assert.Equal(t, tt.expectedError, strings.TrimSpace(err.Error()))
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package tekton
+
+import (
+	"bytes"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// APIVersion is the API version of the generated Tekton objects
+const APIVersion = "tekton.dev/v1beta1"
+
+// ObjectMeta is the metadata of a generated Tekton object
+type ObjectMeta struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Pipeline is a Tekton `Pipeline`.
+//
+// Only the fields used by the generator are defined.
+type Pipeline struct {
+	APIVersion string       `json:"apiVersion"`
+	Kind       string       `json:"kind"`
+	Metadata   ObjectMeta   `json:"metadata"`
+	Spec       PipelineSpec `json:"spec"`
+}
+
+// PipelineSpec is the specification of a Tekton `Pipeline`
+type PipelineSpec struct {
+	Workspaces []PipelineWorkspace `json:"workspaces,omitempty"`
+	Tasks      []PipelineTask      `json:"tasks"`
+}
+
+// PipelineWorkspace is a workspace declared by a pipeline, and provided by its pipeline runs
+type PipelineWorkspace struct {
+	Name string `json:"name"`
+}
+
+// PipelineTask is a task of a pipeline
+type PipelineTask struct {
+	Name       string                  `json:"name"`
+	TaskRef    TaskRef                 `json:"taskRef"`
+	RunAfter   []string                `json:"runAfter,omitempty"`
+	Workspaces []PipelineTaskWorkspace `json:"workspaces,omitempty"`
+}
+
+// TaskRef references the `Task` a pipeline task runs
+type TaskRef struct {
+	Name string `json:"name"`
+}
+
+// PipelineTaskWorkspace binds a workspace of a task to a workspace of its pipeline
+type PipelineTaskWorkspace struct {
+	Name      string `json:"name"`
+	Workspace string `json:"workspace"`
+}
+
+// Task is a Tekton `Task`.
+//
+// Only the fields used by the generator are defined.
+type Task struct {
+	APIVersion string     `json:"apiVersion"`
+	Kind       string     `json:"kind"`
+	Metadata   ObjectMeta `json:"metadata"`
+	Spec       TaskSpec   `json:"spec"`
+}
+
+// TaskSpec is the specification of a Tekton `Task`
+type TaskSpec struct {
+	Workspaces []TaskWorkspace `json:"workspaces,omitempty"`
+	Steps      []Step          `json:"steps"`
+}
+
+// TaskWorkspace is a workspace declared by a task, mounted at the given path in its steps
+type TaskWorkspace struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// Step is a step of a task, which runs either a script or a command in a container
+type Step struct {
+	Name       string          `json:"name"`
+	Image      string          `json:"image"`
+	Command    []string        `json:"command,omitempty"`
+	Args       []string        `json:"args,omitempty"`
+	WorkingDir string          `json:"workingDir,omitempty"`
+	Env        []corev1.EnvVar `json:"env,omitempty"`
+	Script     string          `json:"script,omitempty"`
+}
+
+// Resources are the Tekton objects generated from a devfile
+type Resources struct {
+	Pipeline Pipeline
+	Tasks    []Task
+}
+
+// Marshal returns the YAML content of the pipeline and its tasks, as a multi-document manifest.
+func (r *Resources) Marshal() ([]byte, error) {
+	var documents [][]byte
+	for _, object := range append([]interface{}{r.Pipeline}, tasksAsObjects(r.Tasks)...) {
+		document, err := yaml.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	return bytes.Join(documents, []byte("---\n")), nil
+}
+
+func tasksAsObjects(tasks []Task) []interface{} {
+	var objects []interface{}
+	for _, task := range tasks {
+		objects = append(objects, task)
+	}
+	return objects
+}
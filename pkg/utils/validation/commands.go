@@ -0,0 +1,26 @@
+package validation
+
+import (
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidateCommandGroups checks that each command group has at most one default command.
+//
+// Parents and plugins may bring their own default commands, so the check is expected
+// to run on the flattened content, once the parent and plugins are merged.
+func ValidateCommandGroups(content *workspaces.DevWorkspaceTemplateSpecContent) error {
+	var errors *multierror.Error
+	for _, kind := range []workspaces.CommandGroupKind{
+		workspaces.BuildCommandGroupKind,
+		workspaces.RunCommandGroupKind,
+		workspaces.TestCommandGroupKind,
+		workspaces.DebugCommandGroupKind,
+	} {
+		_, err := content.GetDefaultCommand(kind)
+		if _, multipleDefaults := err.(*workspaces.MultipleDefaultCommandsError); multipleDefaults {
+			errors = multierror.Append(errors, err)
+		}
+	}
+	return errors.ErrorOrNil()
+}
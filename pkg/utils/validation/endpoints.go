@@ -0,0 +1,157 @@
+package validation
+
+import (
+	"fmt"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	minPort = 1
+	maxPort = 65535
+)
+
+// mainPod is the key of the main workspace pod in the port collision checks.
+// Components that run in a dedicated pod are keyed by their name.
+const mainPod = ""
+
+// ValidateEndpoints checks the endpoints of the given components:
+//
+// - the protocol should be one of the supported protocols,
+//
+// - the target port should be between 1 and 65535,
+//
+// - secure endpoints should be exposed outside of the main workspace pod, with an application protocol,
+//
+// - public endpoints should use a protocol that can be routed (http, https, ws, wss, grpc or http2),
+//
+// - endpoints of different container components that run in the same pod should not target the same port.
+//
+// Several endpoints of the same component are allowed to target the same port.
+func ValidateEndpoints(components []workspaces.Component) error {
+	var errors *multierror.Error
+
+	type portOwner struct {
+		component string
+		endpoint  string
+	}
+	portOwnersByPod := map[string]map[int]portOwner{}
+
+	for _, component := range components {
+		for _, endpoint := range componentEndpoints(component) {
+			errors = multierror.Append(errors, validateEndpoint(component.Name, endpoint)...)
+
+			if component.Container == nil || endpoint.TargetPort == 0 {
+				continue
+			}
+			pod := mainPod
+			if component.Container.DedicatedPod {
+				pod = component.Name
+			}
+			portOwners, exists := portOwnersByPod[pod]
+			if !exists {
+				portOwners = map[int]portOwner{}
+				portOwnersByPod[pod] = portOwners
+			}
+			owner, used := portOwners[endpoint.TargetPort]
+			if !used {
+				portOwners[endpoint.TargetPort] = portOwner{component: component.Name, endpoint: endpoint.Name}
+				continue
+			}
+			if owner.component != component.Name {
+				errors = multierror.Append(errors, fmt.Errorf("endpoint '%s' of component '%s' targets port %d, which is already used by endpoint '%s' of component '%s' in the same pod",
+					endpoint.Name, component.Name, endpoint.TargetPort, owner.endpoint, owner.component))
+			}
+		}
+	}
+	return errors.ErrorOrNil()
+}
+
+func validateEndpoint(componentName string, endpoint workspaces.Endpoint) []error {
+	errs := []error{}
+	if endpoint.TargetPort < minPort && endpoint.TargetPort != 0 || endpoint.TargetPort > maxPort {
+		errs = append(errs, fmt.Errorf("endpoint '%s' of component '%s' has an invalid target port %d: it should be between %d and %d",
+			endpoint.Name, componentName, endpoint.TargetPort, minPort, maxPort))
+	}
+
+	protocol := EndpointProtocol(endpoint)
+	if !isSupportedProtocol(protocol) {
+		errs = append(errs, fmt.Errorf("endpoint '%s' of component '%s' has an unsupported protocol '%s'",
+			endpoint.Name, componentName, protocol))
+		return errs
+	}
+	exposure := EndpointExposure(endpoint)
+
+	if IsSecureEndpoint(endpoint) {
+		if !IsApplicationProtocol(protocol) {
+			errs = append(errs, fmt.Errorf("endpoint '%s' of component '%s' cannot be secure with the '%s' protocol",
+				endpoint.Name, componentName, protocol))
+		}
+		if exposure == workspaces.NoneEndpointExposure {
+			errs = append(errs, fmt.Errorf("endpoint '%s' of component '%s' cannot be secure with the '%s' exposure",
+				endpoint.Name, componentName, exposure))
+		}
+	}
+
+	if exposure == workspaces.PublicEndpointExposure && !IsApplicationProtocol(protocol) {
+		errs = append(errs, fmt.Errorf("endpoint '%s' of component '%s' cannot be exposed publicly with the '%s' protocol",
+			endpoint.Name, componentName, protocol))
+	}
+	return errs
+}
+
+func componentEndpoints(component workspaces.Component) []workspaces.Endpoint {
+	switch {
+	case component.Container != nil:
+		return component.Container.Endpoints
+	case component.Kubernetes != nil:
+		return component.Kubernetes.Endpoints
+	case component.Openshift != nil:
+		return component.Openshift.Endpoints
+	}
+	return nil
+}
+
+func isSupportedProtocol(protocol workspaces.EndpointProtocol) bool {
+	switch protocol {
+	case workspaces.TCPEndpointProtocol, workspaces.UDPEndpointProtocol:
+		return true
+	}
+	return IsApplicationProtocol(protocol)
+}
+
+// EndpointProtocol returns the protocol of the endpoint, defaulting to `http`.
+func EndpointProtocol(endpoint workspaces.Endpoint) workspaces.EndpointProtocol {
+	if endpoint.Protocol == "" {
+		return workspaces.HTTPEndpointProtocol
+	}
+	return endpoint.Protocol
+}
+
+// EndpointExposure returns the exposure of the endpoint, defaulting to `public`.
+func EndpointExposure(endpoint workspaces.Endpoint) workspaces.EndpointExposure {
+	if endpoint.Exposure == "" {
+		return workspaces.PublicEndpointExposure
+	}
+	return endpoint.Exposure
+}
+
+// IsSecureEndpoint returns true if the endpoint is secure, either explicitly
+// or because its protocol is `https` or `wss`.
+func IsSecureEndpoint(endpoint workspaces.Endpoint) bool {
+	protocol := EndpointProtocol(endpoint)
+	return endpoint.Secure || protocol == workspaces.HTTPSEndpointProtocol || protocol == workspaces.WSSEndpointProtocol
+}
+
+// IsApplicationProtocol returns true if the protocol is an application protocol
+// (http, https, ws, wss, grpc or http2), as opposed to the `tcp` and `udp` transport protocols.
+func IsApplicationProtocol(protocol workspaces.EndpointProtocol) bool {
+	switch protocol {
+	case workspaces.HTTPEndpointProtocol, workspaces.HTTPSEndpointProtocol,
+		workspaces.WSEndpointProtocol, workspaces.WSSEndpointProtocol,
+		workspaces.GRPCEndpointProtocol, workspaces.HTTP2EndpointProtocol:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package validation
+
+import (
+	"fmt"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidateEnv checks the environment variables of container components and exec commands:
+// a variable should have either a value or a `valueFrom` source, and the Secret and ConfigMap keys
+// of the sources should have a name and a key.
+//
+// The build arguments of image components should have a value, since they are not resolved in the workspace.
+func ValidateEnv(content *workspaces.DevWorkspaceTemplateSpecContent) error {
+	var errors *multierror.Error
+	for _, component := range content.Components {
+		switch {
+		case component.Container != nil:
+			for _, env := range component.Container.Env {
+				if err := validateEnvVar(env); err != nil {
+					errors = multierror.Append(errors, fmt.Errorf("environment variable '%s' of container '%s' %v", env.Name, component.Name, err))
+				}
+			}
+		case component.Image != nil:
+			for _, arg := range component.Image.Args {
+				if arg.ValueFrom != nil {
+					errors = multierror.Append(errors, fmt.Errorf("build argument '%s' of image component '%s' should not use 'valueFrom'", arg.Name, component.Name))
+				}
+			}
+		}
+	}
+	for _, command := range content.Commands {
+		if command.Exec == nil {
+			continue
+		}
+		for _, env := range command.Exec.Env {
+			if err := validateEnvVar(env); err != nil {
+				errors = multierror.Append(errors, fmt.Errorf("environment variable '%s' of command '%s' %v", env.Name, command.Id, err))
+			}
+		}
+	}
+	return errors.ErrorOrNil()
+}
+
+func validateEnvVar(env workspaces.EnvVar) error {
+	if env.ValueFrom == nil {
+		return nil
+	}
+	if env.Value != "" {
+		return fmt.Errorf("should not have both a value and 'valueFrom'")
+	}
+	source := env.ValueFrom
+	var selector *workspaces.KeySelector
+	kind := ""
+	switch {
+	case source.SecretKeyRef != nil:
+		selector, kind = source.SecretKeyRef, "Secret"
+	case source.ConfigMapKeyRef != nil:
+		selector, kind = source.ConfigMapKeyRef, "ConfigMap"
+	case source.WorkspaceRef != nil:
+		if source.WorkspaceRef.Field == "" {
+			return fmt.Errorf("should reference a field of the workspace metadata")
+		}
+		return nil
+	default:
+		return fmt.Errorf("should have a Secret, ConfigMap or workspace source in 'valueFrom'")
+	}
+	if selector.Name == "" || selector.Key == "" {
+		return fmt.Errorf("should reference both the name and a key of a %s", kind)
+	}
+	return nil
+}
+
+// EnvPolicy restricts the sources the environment variables of a devfile can get their values from,
+// for example to prevent devfiles written by untrusted users from reading the Secrets of the namespace.
+//
+// The zero value forbids all the sources.
+type EnvPolicy struct {
+	// Allows reading the keys of Secrets
+	AllowSecrets bool
+	// Allows reading the keys of ConfigMaps
+	AllowConfigMaps bool
+	// Allows reading the metadata of the workspace
+	AllowWorkspaceMetadata bool
+}
+
+// ValidateEnvPolicy checks that the environment variables of container components and exec commands
+// only get their values from the sources allowed by the policy.
+func ValidateEnvPolicy(content *workspaces.DevWorkspaceTemplateSpecContent, policy EnvPolicy) error {
+	var errors *multierror.Error
+	for _, component := range content.Components {
+		if component.Container == nil {
+			continue
+		}
+		for _, env := range component.Container.Env {
+			if source := forbiddenEnvSource(env, policy); source != "" {
+				errors = multierror.Append(errors, fmt.Errorf("environment variable '%s' of container '%s' reads %s, which is forbidden by the policy", env.Name, component.Name, source))
+			}
+		}
+	}
+	for _, command := range content.Commands {
+		if command.Exec == nil {
+			continue
+		}
+		for _, env := range command.Exec.Env {
+			if source := forbiddenEnvSource(env, policy); source != "" {
+				errors = multierror.Append(errors, fmt.Errorf("environment variable '%s' of command '%s' reads %s, which is forbidden by the policy", env.Name, command.Id, source))
+			}
+		}
+	}
+	return errors.ErrorOrNil()
+}
+
+// forbiddenEnvSource returns a description of the source of the environment variable
+// when it is forbidden by the policy, or an empty string otherwise.
+func forbiddenEnvSource(env workspaces.EnvVar, policy EnvPolicy) string {
+	source := env.ValueFrom
+	switch {
+	case source == nil:
+		return ""
+	case source.SecretKeyRef != nil && !policy.AllowSecrets:
+		return fmt.Sprintf("key '%s' of Secret '%s'", source.SecretKeyRef.Key, source.SecretKeyRef.Name)
+	case source.ConfigMapKeyRef != nil && !policy.AllowConfigMaps:
+		return fmt.Sprintf("key '%s' of ConfigMap '%s'", source.ConfigMapKeyRef.Key, source.ConfigMapKeyRef.Name)
+	case source.WorkspaceRef != nil && !policy.AllowWorkspaceMetadata:
+		return fmt.Sprintf("the '%s' of the workspace", source.WorkspaceRef.Field)
+	}
+	return ""
+}
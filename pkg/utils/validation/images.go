@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"fmt"
+	"path"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidateImages checks that image components have an image name,
+// and that their build context and Dockerfile are relative paths inside the project source.
+func ValidateImages(components []workspaces.Component) error {
+	var errors *multierror.Error
+	for _, component := range components {
+		if component.Image == nil {
+			continue
+		}
+		image := component.Image.Image
+		if image.ImageName == "" {
+			errors = multierror.Append(errors, fmt.Errorf("image component '%s' should have an image name", component.Name))
+		}
+		if err := validateBuildContext(image.BuildContext); err != nil {
+			errors = multierror.Append(errors, fmt.Errorf("image component '%s' has an invalid build context '%s': %v", component.Name, image.BuildContext, err))
+			continue
+		}
+		// The Dockerfile may be outside of the build context, but not outside of the project source
+		switch {
+		case path.IsAbs(image.Dockerfile):
+			errors = multierror.Append(errors, fmt.Errorf("image component '%s' has an invalid Dockerfile '%s': it should be relative to the build context", component.Name, image.Dockerfile))
+		case climbsAboveRoot(image.BuildContext + "/" + image.Dockerfile):
+			errors = multierror.Append(errors, fmt.Errorf("image component '%s' has an invalid Dockerfile '%s': it should not climb above the project source", component.Name, image.Dockerfile))
+		}
+	}
+	return errors.ErrorOrNil()
+}
+
+func validateBuildContext(buildContext string) error {
+	if path.IsAbs(buildContext) {
+		return fmt.Errorf("it should be relative to the project source")
+	}
+	if climbsAboveRoot(buildContext) {
+		return fmt.Errorf("it should not climb above the project source")
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidateKeys checks that the elements of each top-level list (commands, components, projects, ...)
+// have unique keys, since keys are used to reference the elements, and to override them from a parent or a plugin.
+func ValidateKeys(container workspaces.TopLevelListContainer) error {
+	var errors *multierror.Error
+	lists := container.GetToplevelLists()
+	var listTypes []string
+	for listType := range lists {
+		listTypes = append(listTypes, listType)
+	}
+	sort.Strings(listTypes)
+	for _, listType := range listTypes {
+		seen := map[string]bool{}
+		var duplicates []string
+		for _, key := range lists[listType].GetKeys() {
+			if seen[key] {
+				duplicates = append(duplicates, key)
+			}
+			seen[key] = true
+		}
+		if len(duplicates) > 0 {
+			errors = multierror.Append(errors, fmt.Errorf("%s have duplicate keys: %s", listType, strings.Join(duplicates, ", ")))
+		}
+	}
+	return errors.ErrorOrNil()
+}
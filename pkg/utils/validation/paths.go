@@ -0,0 +1,226 @@
+package validation
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/hashicorp/go-multierror"
+)
+
+// DefaultSourceMapping is the path at which project sources are expected to be mounted
+// in containers that have `mountSources` without a `sourceMapping`.
+const DefaultSourceMapping = "/projects"
+
+// systemPaths are the container directories over which volumes and sources should not be mounted
+var systemPaths = map[string]bool{
+	"/":        true,
+	"/bin":     true,
+	"/boot":    true,
+	"/etc":     true,
+	"/lib":     true,
+	"/lib64":   true,
+	"/root":    true,
+	"/run":     true,
+	"/sbin":    true,
+	"/usr":     true,
+	"/usr/bin": true,
+	"/usr/lib": true,
+	"/var":     true,
+	"/var/run": true,
+}
+
+// kernelPaths are the container directories inside which volumes and sources should not be mounted
+var kernelPaths = []string{"/dev", "/proc", "/sys"}
+
+// workingDirVariables are the variables a command working directory can start with
+var workingDirVariables = []string{"${PROJECTS_ROOT}", "$PROJECTS_ROOT", "${PROJECT_SOURCE}", "$PROJECT_SOURCE"}
+
+// ValidatePaths checks the paths of a workspace template:
+//
+// - project clone paths should be relative paths inside the projects root,
+// and several projects should not be cloned into the same directory, or into the directory of another project,
+//
+// - source mappings and volume mount paths should be absolute paths that are not system directories,
+// and the volume mounts of a container, including its project sources, should not overlap,
+//
+// - exec command working directories should not climb above the directory they start from.
+//
+// None of these paths may use `..` to climb above their root.
+func ValidatePaths(content *workspaces.DevWorkspaceTemplateSpecContent) error {
+	var errors *multierror.Error
+	errors = multierror.Append(errors, validateProjectPaths(content.Projects))
+	for _, starterProject := range content.StarterProjects {
+		if _, err := CloneDirectory(starterProject.Project); err != nil {
+			errors = multierror.Append(errors, fmt.Errorf("starter %v", err))
+		}
+	}
+	for _, component := range content.Components {
+		if component.Container != nil {
+			errors = multierror.Append(errors, validateContainerMounts(component.Name, component.Container.Container)...)
+		}
+	}
+	for _, command := range content.Commands {
+		if command.Exec != nil {
+			if err := validateWorkingDir(command.Exec.WorkingDir); err != nil {
+				errors = multierror.Append(errors, fmt.Errorf("command '%s' has an invalid working directory '%s': %v", command.Id, command.Exec.WorkingDir, err))
+			}
+		}
+	}
+	return errors.ErrorOrNil()
+}
+
+// CloneDirectory returns the normalized directory, relative to the projects root,
+// into which a project should be cloned: its `clonePath` if specified, or its name otherwise.
+//
+// Returns an error if this directory is not inside the projects root.
+func CloneDirectory(project workspaces.Project) (string, error) {
+	clonePath := project.ClonePath
+	if clonePath == "" {
+		clonePath = project.Name
+	}
+	if strings.Contains(clonePath, "\\") {
+		return "", fmt.Errorf("project '%s' has an invalid clone path '%s': it should be a unix-style path", project.Name, clonePath)
+	}
+	if path.IsAbs(clonePath) {
+		return "", fmt.Errorf("project '%s' has an invalid clone path '%s': it should be relative to the projects root", project.Name, clonePath)
+	}
+	cleaned := path.Clean(clonePath)
+	if climbsAboveRoot(clonePath) || cleaned == "." {
+		return "", fmt.Errorf("project '%s' has an invalid clone path '%s': it should be a directory inside the projects root", project.Name, clonePath)
+	}
+	return cleaned, nil
+}
+
+func validateProjectPaths(projects []workspaces.Project) error {
+	var errors *multierror.Error
+	type clonedProject struct {
+		name string
+		dir  string
+	}
+	cloned := []clonedProject{}
+	for _, project := range projects {
+		dir, err := CloneDirectory(project)
+		if err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+		for _, other := range cloned {
+			switch {
+			case dir == other.dir:
+				errors = multierror.Append(errors, fmt.Errorf("projects '%s' and '%s' are cloned into the same directory '%s'", other.name, project.Name, dir))
+			case isInside(dir, other.dir):
+				errors = multierror.Append(errors, fmt.Errorf("project '%s' is cloned into '%s', inside the directory of project '%s'", project.Name, dir, other.name))
+			case isInside(other.dir, dir):
+				errors = multierror.Append(errors, fmt.Errorf("project '%s' is cloned into '%s', inside the directory of project '%s'", other.name, other.dir, project.Name))
+			}
+		}
+		cloned = append(cloned, clonedProject{name: project.Name, dir: dir})
+	}
+	return errors.ErrorOrNil()
+}
+
+func validateContainerMounts(componentName string, container workspaces.Container) []error {
+	errs := []error{}
+	type mount struct {
+		description string
+		path        string
+	}
+	mounts := []mount{}
+
+	if container.SourceMapping != "" || container.MountSources {
+		sourceMapping := container.SourceMapping
+		if sourceMapping == "" {
+			sourceMapping = DefaultSourceMapping
+		}
+		if err := validateMountPath(sourceMapping); err != nil {
+			errs = append(errs, fmt.Errorf("container component '%s' has an invalid source mapping '%s': %v", componentName, sourceMapping, err))
+		} else if container.MountSources {
+			mounts = append(mounts, mount{description: "project sources", path: path.Clean(sourceMapping)})
+		}
+	}
+	for _, volumeMount := range container.VolumeMounts {
+		mountPath := volumeMount.Path
+		if mountPath == "" {
+			mountPath = "/" + volumeMount.Name
+		}
+		if err := validateMountPath(mountPath); err != nil {
+			errs = append(errs, fmt.Errorf("container component '%s' has an invalid mount path '%s' for volume '%s': %v", componentName, mountPath, volumeMount.Name, err))
+			continue
+		}
+		mounts = append(mounts, mount{description: fmt.Sprintf("volume '%s'", volumeMount.Name), path: path.Clean(mountPath)})
+	}
+
+	for i, first := range mounts {
+		for _, second := range mounts[i+1:] {
+			if first.path == second.path || isInside(first.path, second.path) || isInside(second.path, first.path) {
+				errs = append(errs, fmt.Errorf("mounts of container component '%s' overlap: %s at '%s' and %s at '%s'",
+					componentName, first.description, first.path, second.description, second.path))
+			}
+		}
+	}
+	return errs
+}
+
+func validateMountPath(mountPath string) error {
+	if !path.IsAbs(mountPath) {
+		return fmt.Errorf("it should be an absolute path")
+	}
+	if climbsAboveRoot(mountPath) {
+		return fmt.Errorf("it should not climb above the root directory")
+	}
+	cleaned := path.Clean(mountPath)
+	if systemPaths[cleaned] {
+		return fmt.Errorf("it should not be a system directory")
+	}
+	for _, kernelPath := range kernelPaths {
+		if cleaned == kernelPath || isInside(cleaned, kernelPath) {
+			return fmt.Errorf("it should not be inside the '%s' system directory", kernelPath)
+		}
+	}
+	return nil
+}
+
+func validateWorkingDir(workingDir string) error {
+	root := "its root directory"
+	remainder := workingDir
+	for _, variable := range workingDirVariables {
+		if strings.HasPrefix(workingDir, variable) {
+			root = variable
+			remainder = strings.TrimPrefix(workingDir, variable)
+			break
+		}
+	}
+	if climbsAboveRoot(remainder) {
+		return fmt.Errorf("it should not climb above %s", root)
+	}
+	return nil
+}
+
+// climbsAboveRoot returns true if, when its segments are processed in order,
+// the path uses `..` to climb above the directory it starts from.
+func climbsAboveRoot(p string) bool {
+	depth := 0
+	for _, segment := range strings.Split(p, "/") {
+		switch segment {
+		case "", ".":
+		case "..":
+			depth--
+			if depth < 0 {
+				return true
+			}
+		default:
+			depth++
+		}
+	}
+	return false
+}
+
+// isInside returns true if the cleaned path `p` is strictly inside the cleaned directory `dir`
+func isInside(p, dir string) bool {
+	if dir == "/" {
+		return p != "/"
+	}
+	return strings.HasPrefix(p, dir+"/")
+}
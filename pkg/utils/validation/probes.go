@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"fmt"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidateProbes checks the readiness and liveness probes of container components:
+//
+// - HTTP and TCP probes should reference an endpoint of the container, which doesn't use the `udp` protocol,
+//
+// - exec probes should have a command.
+func ValidateProbes(components []workspaces.Component) error {
+	var errors *multierror.Error
+	for _, component := range components {
+		if component.Container == nil {
+			continue
+		}
+		container := component.Container
+		if container.ReadinessProbe != nil {
+			if err := validateProbe(container.Endpoints, container.ReadinessProbe); err != nil {
+				errors = multierror.Append(errors, fmt.Errorf("readiness probe of container '%s' %v", component.Name, err))
+			}
+		}
+		if container.LivenessProbe != nil {
+			if err := validateProbe(container.Endpoints, container.LivenessProbe); err != nil {
+				errors = multierror.Append(errors, fmt.Errorf("liveness probe of container '%s' %v", component.Name, err))
+			}
+		}
+	}
+	return errors.ErrorOrNil()
+}
+
+func validateProbe(endpoints []workspaces.Endpoint, probe *workspaces.Probe) error {
+	switch {
+	case probe.Http != nil:
+		return validateProbeEndpoint(endpoints, probe.Http.Endpoint)
+	case probe.Tcp != nil:
+		return validateProbeEndpoint(endpoints, probe.Tcp.Endpoint)
+	case probe.Exec != nil:
+		if len(probe.Exec.Command) == 0 {
+			return fmt.Errorf("should have a command")
+		}
+		return nil
+	}
+	return fmt.Errorf("should be an HTTP, TCP or exec probe")
+}
+
+func validateProbeEndpoint(endpoints []workspaces.Endpoint, name string) error {
+	for _, endpoint := range endpoints {
+		if endpoint.Name != name {
+			continue
+		}
+		if endpoint.Protocol == workspaces.UDPEndpointProtocol {
+			return fmt.Errorf("references endpoint '%s', which uses the 'udp' protocol", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("references endpoint '%s', which doesn't exist in the container", name)
+}
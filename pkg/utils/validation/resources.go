@@ -0,0 +1,31 @@
+package validation
+
+import (
+	"fmt"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/resources"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidateContainerResources checks that the memory and CPU limits and requests
+// of the container components are valid resource quantities,
+// and that requests are not greater than limits.
+func ValidateContainerResources(components []workspaces.Component) error {
+	var errors *multierror.Error
+	for _, component := range components {
+		if component.Container == nil {
+			continue
+		}
+		if _, err := resources.ContainerResources(component.Container.Container); err != nil {
+			if merr, isMultiError := err.(*multierror.Error); isMultiError {
+				for _, containerErr := range merr.Errors {
+					errors = multierror.Append(errors, fmt.Errorf("container component '%s': %v", component.Name, containerErr))
+				}
+				continue
+			}
+			errors = multierror.Append(errors, fmt.Errorf("container component '%s': %v", component.Name, err))
+		}
+	}
+	return errors.ErrorOrNil()
+}
@@ -0,0 +1,27 @@
+// Package validation provides semantic checks of devfile and workspace template content,
+// that cannot be expressed by the OpenAPI schema of the custom resources.
+package validation
+
+import (
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidateWorkspaceTemplate checks the content of a workspace template,
+// and returns all the validation errors found, aggregated into a single error.
+//
+// The content is expected to be flattened (without any parent or plugin),
+// since some checks, like port collisions, depend on all the components of the workspace.
+func ValidateWorkspaceTemplate(content *workspaces.DevWorkspaceTemplateSpecContent) error {
+	var errors *multierror.Error
+	errors = multierror.Append(errors, ValidateKeys(content))
+	errors = multierror.Append(errors, ValidateEndpoints(content.Components))
+	errors = multierror.Append(errors, ValidateContainerResources(content.Components))
+	errors = multierror.Append(errors, ValidatePaths(content))
+	errors = multierror.Append(errors, ValidateImages(content.Components))
+	errors = multierror.Append(errors, ValidateProbes(content.Components))
+	errors = multierror.Append(errors, ValidateEnv(content))
+	errors = multierror.Append(errors, ValidateVolumes(content.Components))
+	errors = multierror.Append(errors, ValidateCommandGroups(content))
+	return errors.ErrorOrNil()
+}
@@ -0,0 +1,143 @@
+package validation
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/overriding"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+// TestValidateWorkspaceTemplate runs the tests found in the `test-fixtures` folder.
+// Each test folder contains a `workspace-template.yaml` file with the flattened workspace template content to validate,
+// and an optional `result-error.txt` file with the expected validation errors.
+func TestValidateWorkspaceTemplate(t *testing.T) {
+	filepath.Walk("test-fixtures", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+		if info.IsDir() || info.Name() != "workspace-template.yaml" {
+			return nil
+		}
+		dirPath := filepath.Dir(path)
+		t.Run(filepath.Base(dirPath), func(t *testing.T) {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			template := &workspaces.DevWorkspaceTemplateSpecContent{}
+			if err := yaml.Unmarshal(content, template); err != nil {
+				t.Fatal(err)
+			}
+
+			expectedError := ""
+			if expectedErrorBytes, err := ioutil.ReadFile(filepath.Join(dirPath, "result-error.txt")); err == nil {
+				expectedError = strings.TrimSpace(string(expectedErrorBytes))
+			}
+
+			err = ValidateWorkspaceTemplate(template)
+			if expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Equal(t, expectedError, strings.TrimSpace(err.Error()), "wrong error")
+			}
+		})
+		return nil
+	})
+}
+
+func TestValidateEnvPolicy(t *testing.T) {
+	content := &workspaces.DevWorkspaceTemplateSpecContent{}
+	err := yaml.Unmarshal([]byte(`
+components:
+  - name: app
+    container:
+      image: quay.io/example/app:latest
+      env:
+        - name: API_TOKEN
+          valueFrom:
+            secretKeyRef:
+              name: api-credentials
+              key: token
+        - name: LOG_LEVEL
+          valueFrom:
+            configMapKeyRef:
+              name: app-config
+              key: logLevel
+commands:
+  - id: deploy
+    exec:
+      component: app
+      commandLine: ./deploy.sh
+      env:
+        - name: NAMESPACE
+          valueFrom:
+            workspaceRef:
+              field: namespace
+`), content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ValidateEnvPolicy(content, EnvPolicy{AllowConfigMaps: true})
+	if assert.Error(t, err) {
+		assert.Equal(t, `2 errors occurred:
+	* environment variable 'API_TOKEN' of container 'app' reads key 'token' of Secret 'api-credentials', which is forbidden by the policy
+	* environment variable 'NAMESPACE' of command 'deploy' reads the 'namespace' of the workspace, which is forbidden by the policy`, strings.TrimSpace(err.Error()))
+	}
+	assert.NoError(t, ValidateEnvPolicy(content, EnvPolicy{AllowSecrets: true, AllowConfigMaps: true, AllowWorkspaceMetadata: true}))
+}
+
+func TestValidateCommandGroupsAfterMerge(t *testing.T) {
+	parse := func(devfile string) *workspaces.DevWorkspaceTemplateSpecContent {
+		content := &workspaces.DevWorkspaceTemplateSpecContent{}
+		if err := yaml.Unmarshal([]byte(devfile), content); err != nil {
+			t.Fatal(err)
+		}
+		return content
+	}
+	main := parse(`
+commands:
+  - id: build
+    exec:
+      component: tools
+      commandLine: npm run build
+      group:
+        kind: build
+        isDefault: true
+`)
+	parent := parse(`
+components:
+  - name: tools
+    container:
+      image: quay.io/example/tools:latest
+commands:
+  - id: parent-build
+    exec:
+      component: tools
+      commandLine: make
+      group:
+        kind: build
+        isDefault: true
+`)
+	assert.NoError(t, ValidateCommandGroups(main))
+	assert.NoError(t, ValidateCommandGroups(parent))
+
+	merged, err := overriding.MergeDevWorkspaceTemplateSpec(main, parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ValidateCommandGroups(merged)
+	if assert.Error(t, err) {
+		assert.Equal(t, `1 error occurred:
+	* the 'build' command group has several default commands: 'parent-build', 'build'`, strings.TrimSpace(err.Error()))
+	}
+}
@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"fmt"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ValidateVolumes checks the volume components:
+//
+// - the size should be a positive resource quantity,
+//
+// - ephemeral volumes should neither have a storage class or an access mode, nor be shared with the namespace,
+//
+// - ephemeral volumes should only be mounted by containers that run in the same pod,
+// since their content is not persisted outside of the pod.
+func ValidateVolumes(components []workspaces.Component) error {
+	var errors *multierror.Error
+	podsByVolume := map[string]map[string]bool{}
+	for _, component := range components {
+		switch {
+		case component.Volume != nil:
+			volume := component.Volume.Volume
+			if volume.Size != "" {
+				quantity, err := resource.ParseQuantity(volume.Size)
+				switch {
+				case err != nil:
+					errors = multierror.Append(errors, fmt.Errorf("volume '%s' has an invalid size '%s': it should be a valid resource quantity", component.Name, volume.Size))
+				case quantity.Sign() <= 0:
+					errors = multierror.Append(errors, fmt.Errorf("volume '%s' has an invalid size '%s': it should be positive", component.Name, volume.Size))
+				}
+			}
+			if !volume.Ephemeral {
+				continue
+			}
+			if volume.StorageClass != "" {
+				errors = multierror.Append(errors, fmt.Errorf("ephemeral volume '%s' should not have a storage class", component.Name))
+			}
+			if volume.AccessMode != "" {
+				errors = multierror.Append(errors, fmt.Errorf("ephemeral volume '%s' should not have an access mode", component.Name))
+			}
+			if volume.Sharing == workspaces.NamespaceVolumeSharing {
+				errors = multierror.Append(errors, fmt.Errorf("ephemeral volume '%s' should not be shared with the namespace", component.Name))
+			}
+		case component.Container != nil:
+			pod := mainPod
+			if component.Container.DedicatedPod {
+				pod = component.Name
+			}
+			for _, mount := range component.Container.VolumeMounts {
+				if podsByVolume[mount.Name] == nil {
+					podsByVolume[mount.Name] = map[string]bool{}
+				}
+				podsByVolume[mount.Name][pod] = true
+			}
+		}
+	}
+	for _, component := range components {
+		if component.Volume != nil && component.Volume.Ephemeral && len(podsByVolume[component.Name]) > 1 {
+			errors = multierror.Append(errors, fmt.Errorf("ephemeral volume '%s' is mounted by containers that run in different pods", component.Name))
+		}
+	}
+	return errors.ErrorOrNil()
+}
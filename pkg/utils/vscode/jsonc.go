@@ -0,0 +1,64 @@
+package vscode
+
+// StripJSONC converts a JSON with comments (JSONC) document, such as a VS Code `tasks.json`
+// or `launch.json` file, to standard JSON, by removing `//` and `/* */` comments
+// as well as trailing commas in objects and arrays.
+func StripJSONC(content []byte) []byte {
+	result := make([]byte, 0, len(content))
+	// position in result of a comma that may be a trailing one, or -1
+	pendingComma := -1
+	inString := false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if inString {
+			result = append(result, c)
+			switch c {
+			case '\\':
+				if i+1 < len(content) {
+					i++
+					result = append(result, content[i])
+				}
+			case '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			if i < len(content) {
+				result = append(result, '\n')
+			}
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			i += 2
+			for i < len(content) && !(content[i] == '*' && i+1 < len(content) && content[i+1] == '/') {
+				if content[i] == '\n' {
+					result = append(result, '\n')
+				}
+				i++
+			}
+			i++
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			result = append(result, c)
+		case c == '}' || c == ']':
+			if pendingComma >= 0 {
+				result[pendingComma] = ' '
+				pendingComma = -1
+			}
+			result = append(result, c)
+		case c == ',':
+			pendingComma = len(result)
+			result = append(result, c)
+		default:
+			pendingComma = -1
+			if c == '"' {
+				inString = true
+			}
+			result = append(result, c)
+		}
+	}
+	return result
+}
@@ -0,0 +1,209 @@
+package vscode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// TasksVersion is the version of the `tasks.json` format generated by this package
+const TasksVersion = "2.0.0"
+
+// LaunchVersion is the current version of the `launch.json` format
+const LaunchVersion = "0.2.0"
+
+// Task types supported by the conversion to devfile commands
+const (
+	ShellTaskType   = "shell"
+	ProcessTaskType = "process"
+)
+
+// VS Code task groups
+const (
+	BuildTaskGroup = "build"
+	TestTaskGroup  = "test"
+	NoneTaskGroup  = "none"
+)
+
+// Orders in which VS Code runs the tasks a task depends on
+const (
+	SequenceDependsOrder = "sequence"
+	ParallelDependsOrder = "parallel"
+)
+
+// TasksFile is the content of a VS Code `tasks.json` file
+type TasksFile struct {
+	Version string `json:"version"`
+	Tasks   []Task `json:"tasks,omitempty"`
+}
+
+// Task is a task of a VS Code `tasks.json` file
+type Task struct {
+	Label        string          `json:"label"`
+	Type         string          `json:"type,omitempty"`
+	Command      *TaskArg        `json:"command,omitempty"`
+	Args         []TaskArg       `json:"args,omitempty"`
+	Options      *TaskOptions    `json:"options,omitempty"`
+	Group        *TaskGroup      `json:"group,omitempty"`
+	DependsOn    StringList      `json:"dependsOn,omitempty"`
+	DependsOrder string          `json:"dependsOrder,omitempty"`
+	IsBackground bool            `json:"isBackground,omitempty"`
+	Detail       string          `json:"detail,omitempty"`
+	Presentation json.RawMessage `json:"presentation,omitempty"`
+	// ProblemMatcher is kept as-is, since it is not used by the conversion
+	ProblemMatcher json.RawMessage `json:"problemMatcher,omitempty"`
+}
+
+// TaskOptions are the options of a VS Code task
+type TaskOptions struct {
+	Cwd string            `json:"cwd,omitempty"`
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// TaskArg is the command or an argument of a VS Code task.
+// In `tasks.json`, they are either a string, or an object with a `value` and a `quoting` style.
+type TaskArg struct {
+	Value   string `json:"value"`
+	Quoting string `json:"quoting,omitempty"`
+}
+
+// UnmarshalJSON accepts both the string and the object forms of task commands and arguments.
+func (a *TaskArg) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*a = TaskArg{Value: value}
+		return nil
+	}
+	type taskArg TaskArg
+	return json.Unmarshal(data, (*taskArg)(a))
+}
+
+// MarshalJSON uses the string form for commands and arguments without a quoting style.
+func (a TaskArg) MarshalJSON() ([]byte, error) {
+	if a.Quoting == "" {
+		return json.Marshal(a.Value)
+	}
+	type taskArg TaskArg
+	return json.Marshal(taskArg(a))
+}
+
+// TaskGroup is the group of a VS Code task.
+// In `tasks.json`, a group is either a string, or an object with a `kind` and an `isDefault` flag.
+type TaskGroup struct {
+	Kind      string `json:"kind"`
+	IsDefault bool   `json:"isDefault,omitempty"`
+}
+
+// UnmarshalJSON accepts both the string and the object forms of task groups.
+func (g *TaskGroup) UnmarshalJSON(data []byte) error {
+	var kind string
+	if err := json.Unmarshal(data, &kind); err == nil {
+		*g = TaskGroup{Kind: kind}
+		return nil
+	}
+	type taskGroup TaskGroup
+	return json.Unmarshal(data, (*taskGroup)(g))
+}
+
+// MarshalJSON uses the string form for groups that are not the default one.
+func (g TaskGroup) MarshalJSON() ([]byte, error) {
+	if !g.IsDefault {
+		return json.Marshal(g.Kind)
+	}
+	type taskGroup TaskGroup
+	return json.Marshal(taskGroup(g))
+}
+
+// StringList is a list of strings that, in VS Code configuration files,
+// may also be written as a single string.
+type StringList []string
+
+// UnmarshalJSON accepts both a single string and a list of strings.
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*l = StringList{value}
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*l = values
+	return nil
+}
+
+// LaunchFile is the content of a VS Code `launch.json` file
+type LaunchFile struct {
+	Version        string                `json:"version"`
+	Configurations []LaunchConfiguration `json:"configurations,omitempty"`
+	Compounds      []LaunchCompound      `json:"compounds,omitempty"`
+}
+
+// LaunchConfiguration is a debug configuration of a VS Code `launch.json` file.
+//
+// Only the attributes common to all the debuggers are typed.
+// The debugger-specific attributes are available in `Attributes`.
+type LaunchConfiguration struct {
+	Name          string
+	Type          string
+	Request       string
+	PreLaunchTask string
+	// Debugger-specific attributes
+	Attributes map[string]json.RawMessage
+}
+
+var launchConfigurationFields = []string{"name", "type", "request", "preLaunchTask"}
+
+// UnmarshalJSON reads the common attributes and keeps the other ones in `Attributes`.
+func (c *LaunchConfiguration) UnmarshalJSON(data []byte) error {
+	attributes := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return err
+	}
+	*c = LaunchConfiguration{}
+	for i, field := range []*string{&c.Name, &c.Type, &c.Request, &c.PreLaunchTask} {
+		name := launchConfigurationFields[i]
+		raw, isSet := attributes[name]
+		if !isSet {
+			continue
+		}
+		if err := json.Unmarshal(raw, field); err != nil {
+			return fmt.Errorf("invalid '%s' attribute in launch configuration: %v", name, err)
+		}
+		delete(attributes, name)
+	}
+	if len(attributes) > 0 {
+		c.Attributes = attributes
+	}
+	return nil
+}
+
+// MarshalJSON writes the common attributes and the debugger-specific ones in the same object.
+func (c LaunchConfiguration) MarshalJSON() ([]byte, error) {
+	attributes := map[string]interface{}{}
+	for name, value := range c.Attributes {
+		attributes[name] = value
+	}
+	for i, value := range []string{c.Name, c.Type, c.Request, c.PreLaunchTask} {
+		if value != "" {
+			attributes[launchConfigurationFields[i]] = value
+		}
+	}
+	// HTML characters are only escaped by encoders that escape them, as `json.Marshal` does
+	marshaled := &bytes.Buffer{}
+	encoder := json.NewEncoder(marshaled)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(attributes); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(marshaled.Bytes(), []byte("\n")), nil
+}
+
+// LaunchCompound is a compound of a VS Code `launch.json` file,
+// which starts several debug configurations together.
+type LaunchCompound struct {
+	Name           string   `json:"name"`
+	Configurations []string `json:"configurations"`
+	PreLaunchTask  string   `json:"preLaunchTask,omitempty"`
+}
@@ -0,0 +1,500 @@
+package vscode
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/pkg/utils/fetching"
+	"github.com/hashicorp/go-multierror"
+)
+
+// LoadConfiguration returns the content of the VS Code configuration of a `vscodeTask`
+// or `vscodeLaunch` command: either the inlined content, or the content fetched from its uri,
+// resolved against `base`, the location of the devfile.
+// When `fetcher` is nil, `fetching.DefaultFetcher` is used.
+func LoadConfiguration(command *workspaces.VscodeConfigurationCommand, fetcher fetching.Fetcher, base string) ([]byte, error) {
+	if command.Inlined != "" {
+		return []byte(command.Inlined), nil
+	}
+	if command.Uri == "" {
+		return nil, fmt.Errorf("the VS Code configuration command has neither an inlined content nor a uri")
+	}
+	uri, err := fetching.ResolveURI(base, command.Uri)
+	if err != nil {
+		return nil, err
+	}
+	if fetcher == nil {
+		fetcher = fetching.DefaultFetcher
+	}
+	return fetcher.Fetch(uri)
+}
+
+// ParseTasks parses the JSONC content of a VS Code `tasks.json` file.
+func ParseTasks(content []byte) (*TasksFile, error) {
+	tasks := &TasksFile{}
+	if err := json.Unmarshal(StripJSONC(content), tasks); err != nil {
+		return nil, fmt.Errorf("failed to parse VS Code tasks: %v", err)
+	}
+	return tasks, nil
+}
+
+// ParseLaunch parses the JSONC content of a VS Code `launch.json` file.
+func ParseLaunch(content []byte) (*LaunchFile, error) {
+	launch := &LaunchFile{}
+	if err := json.Unmarshal(StripJSONC(content), launch); err != nil {
+		return nil, fmt.Errorf("failed to parse VS Code launch configurations: %v", err)
+	}
+	return launch, nil
+}
+
+// TasksToCommands converts the tasks of a VS Code `tasks.json` file to devfile commands
+// that run in the given component:
+//
+// - `shell` and `process` tasks become `exec` commands, with the task arguments appended to the command line,
+// and the `build` and `test` groups mapped to the corresponding command groups.
+// As in VS Code, tasks without a type are `process` tasks,
+//
+// - tasks that only depend on other tasks become `composite` commands.
+//
+// Command ids are derived from the task labels. In the working directory and environment variables,
+// `${workspaceFolder}` is replaced by `${PROJECT_SOURCE}`, and `${env:NAME}` by `${NAME}`.
+//
+// Returns an error for each task that cannot be converted.
+func TasksToCommands(tasks *TasksFile, component string) ([]workspaces.Command, error) {
+	var labels []string
+	for _, task := range tasks.Tasks {
+		labels = append(labels, task.Label)
+	}
+	ids := map[string]string{}
+	for i, id := range commandIds(labels) {
+		ids[labels[i]] = id
+	}
+
+	var errors *multierror.Error
+	var commands []workspaces.Command
+	for _, task := range tasks.Tasks {
+		command, err := taskToCommand(task, ids, component)
+		if err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+		commands = append(commands, command)
+	}
+	if err := errors.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
+
+func taskToCommand(task Task, ids map[string]string, component string) (workspaces.Command, error) {
+	command := workspaces.Command{Id: ids[task.Label]}
+
+	if len(task.DependsOn) > 0 {
+		if task.Command != nil && task.Command.Value != "" {
+			return command, fmt.Errorf("task '%s' both runs a command and depends on other tasks, which is not supported", task.Label)
+		}
+		composite := &workspaces.CompositeCommand{
+			Parallel: task.DependsOrder != SequenceDependsOrder,
+		}
+		composite.Label = task.Label
+		composite.Group = commandGroup(task.Group)
+		for _, dependency := range task.DependsOn {
+			id, exists := ids[dependency]
+			if !exists {
+				return command, fmt.Errorf("task '%s' depends on task '%s', which doesn't exist", task.Label, dependency)
+			}
+			composite.Commands = append(composite.Commands, id)
+		}
+		command.Composite = composite
+		return command, nil
+	}
+
+	taskType := task.Type
+	if taskType == "" {
+		taskType = ProcessTaskType
+	}
+	switch taskType {
+	case ShellTaskType, ProcessTaskType:
+	default:
+		return command, fmt.Errorf("task '%s' has type '%s', but only '%s' and '%s' tasks can be converted", task.Label, task.Type, ShellTaskType, ProcessTaskType)
+	}
+	if task.Command == nil || task.Command.Value == "" {
+		return command, fmt.Errorf("task '%s' has no command", task.Label)
+	}
+
+	words := []string{task.Command.Value}
+	if quoting := task.Command.Quoting; quoting != "" || taskType == ProcessTaskType {
+		if quoting == "" {
+			quoting = "strong"
+		}
+		words[0] = quote(task.Command.Value, quoting)
+	}
+	for _, arg := range task.Args {
+		quoting := arg.Quoting
+		if taskType == ProcessTaskType && quoting == "" {
+			quoting = "strong"
+		}
+		words = append(words, quote(arg.Value, quoting))
+	}
+	exec := &workspaces.ExecCommand{
+		CommandLine: strings.Join(words, " "),
+		Component:   component,
+	}
+	exec.Label = task.Label
+	exec.Group = commandGroup(task.Group)
+	if task.Options != nil {
+		exec.WorkingDir = toDevfileVariables(task.Options.Cwd)
+		var names []string
+		for name := range task.Options.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			exec.Env = append(exec.Env, workspaces.EnvVar{Name: name, Value: toDevfileVariables(task.Options.Env[name])})
+		}
+	}
+	command.Exec = exec
+	return command, nil
+}
+
+var invalidIdCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+// maxIdLength is the maximum length of command ids, which are DNS-1123 labels
+const maxIdLength = 63
+
+// commandIds derives a unique command id from each task label or configuration name, in the same order.
+// Ids are truncated to `maxIdLength` characters, including the suffix that makes them unique.
+func commandIds(names []string) []string {
+	var ids []string
+	used := map[string]bool{}
+	for _, name := range names {
+		base := strings.Trim(invalidIdCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-")
+		if base == "" {
+			base = "task"
+		}
+		id := truncateId(base, "")
+		for i := 2; used[id]; i++ {
+			id = truncateId(base, "-"+strconv.Itoa(i))
+		}
+		used[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// truncateId appends the suffix to the base id, truncating the base id so that the result fits in `maxIdLength` characters
+func truncateId(base string, suffix string) string {
+	if len(base)+len(suffix) > maxIdLength {
+		base = strings.TrimRight(base[:maxIdLength-len(suffix)], "-")
+	}
+	return base + suffix
+}
+
+func commandGroup(group *TaskGroup) *workspaces.CommandGroup {
+	if group == nil {
+		return nil
+	}
+	switch group.Kind {
+	case BuildTaskGroup:
+		return &workspaces.CommandGroup{Kind: workspaces.BuildCommandGroupKind, IsDefault: group.IsDefault}
+	case TestTaskGroup:
+		return &workspaces.CommandGroup{Kind: workspaces.TestCommandGroupKind, IsDefault: group.IsDefault}
+	}
+	return nil
+}
+
+var escapedCharacters = regexp.MustCompile(`([\s'"\\$` + "`" + `])`)
+
+// doubleQuotedCharacters are the characters escaped in double quotes: the `$` that don't start
+// a `$NAME` or `${NAME}` variable reference are escaped, so that variables are expanded,
+// but not command substitutions and other parameter expansions.
+var doubleQuotedCharacters = regexp.MustCompile(`["\\` + "`" + `]|\$(?:[A-Za-z_][A-Za-z0-9_]*|\{[A-Za-z_][A-Za-z0-9_]*\})?`)
+
+// quote quotes a task argument with the given VS Code quoting style:
+// `escape` escapes the special characters, `strong` uses single quotes and `weak` uses double quotes.
+// Without a quoting style, arguments that contain spaces are quoted with double quotes, as VS Code does.
+func quote(value string, quoting string) string {
+	switch quoting {
+	case "escape":
+		return escapedCharacters.ReplaceAllString(value, `\$1`)
+	case "strong":
+		if value != "" && !strings.ContainsAny(value, " \t\n'\"\\$`*?[]{}()<>|&;#~") {
+			return value
+		}
+		return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+	case "weak":
+		return doubleQuote(value)
+	}
+	if strings.ContainsAny(value, " \t") {
+		return doubleQuote(value)
+	}
+	return value
+}
+
+func doubleQuote(value string) string {
+	return `"` + doubleQuotedCharacters.ReplaceAllStringFunc(value, func(special string) string {
+		if len(special) > 1 {
+			return special
+		}
+		return `\` + special
+	}) + `"`
+}
+
+var vscodeEnvVariable = regexp.MustCompile(`\$\{env:([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+func toDevfileVariables(value string) string {
+	value = strings.ReplaceAll(value, "${workspaceFolder}", "${PROJECT_SOURCE}")
+	return vscodeEnvVariable.ReplaceAllString(value, "$${$1}")
+}
+
+var devfileProjectVariables = regexp.MustCompile(`\$\{?(PROJECT_SOURCE|PROJECTS_ROOT)\b\}?`)
+
+func toVscodeVariables(value string) string {
+	return devfileProjectVariables.ReplaceAllStringFunc(value, func(variable string) string {
+		if strings.Contains(variable, "PROJECT_SOURCE") {
+			return "${workspaceFolder}"
+		}
+		return "${env:PROJECTS_ROOT}"
+	})
+}
+
+// CommandsToTasks generates the content of a VS Code `tasks.json` file
+// from the `exec` and `composite` commands of a devfile:
+//
+// - `exec` commands become `shell` tasks,
+//
+// - `composite` commands become tasks that depend on the tasks of their sub-commands,
+//
+// - the `build` and `test` command groups are mapped to the corresponding task groups.
+// The `run` and `debug` groups have no VS Code equivalent and are not kept.
+//
+// Task labels are the command labels, or the command ids when there is no label.
+// In working directories and environment variables, `${PROJECT_SOURCE}` is replaced
+// by `${workspaceFolder}`, and `${PROJECTS_ROOT}` by `${env:PROJECTS_ROOT}`.
+// Commands of other types are ignored.
+func CommandsToTasks(commands []workspaces.Command) (*TasksFile, error) {
+	labels := map[string]string{}
+	labelOwners := map[string]string{}
+	var errors *multierror.Error
+	for _, command := range commands {
+		label := ""
+		switch {
+		case command.Exec != nil:
+			label = command.Exec.Label
+		case command.Composite != nil:
+			label = command.Composite.Label
+		default:
+			continue
+		}
+		if label == "" {
+			label = command.Id
+		}
+		if owner, isUsed := labelOwners[label]; isUsed {
+			errors = multierror.Append(errors, fmt.Errorf("commands '%s' and '%s' would both generate a task with label '%s'", owner, command.Id, label))
+			continue
+		}
+		labelOwners[label] = command.Id
+		labels[command.Id] = label
+	}
+
+	tasks := &TasksFile{Version: TasksVersion}
+	for _, command := range commands {
+		label, isConverted := labels[command.Id]
+		if !isConverted {
+			continue
+		}
+		task := Task{Label: label}
+		switch {
+		case command.Exec != nil:
+			task.Type = ShellTaskType
+			task.Command = &TaskArg{Value: command.Exec.CommandLine}
+			task.Group = taskGroup(command.Exec.Group)
+			if command.Exec.WorkingDir != "" || len(command.Exec.Env) > 0 {
+				task.Options = &TaskOptions{Cwd: toVscodeVariables(command.Exec.WorkingDir)}
+				for _, env := range command.Exec.Env {
+					if env.ValueFrom != nil {
+						// Only the workspace can resolve the Secrets, ConfigMaps and metadata the value comes from
+						continue
+					}
+					if task.Options.Env == nil {
+						task.Options.Env = map[string]string{}
+					}
+					task.Options.Env[env.Name] = toVscodeVariables(env.Value)
+				}
+			}
+		case command.Composite != nil:
+			task.Group = taskGroup(command.Composite.Group)
+			task.DependsOrder = SequenceDependsOrder
+			if command.Composite.Parallel {
+				task.DependsOrder = ParallelDependsOrder
+			}
+			for _, subCommand := range command.Composite.Commands {
+				subLabel, isConverted := labels[subCommand]
+				if !isConverted {
+					errors = multierror.Append(errors, fmt.Errorf("composite command '%s' references command '%s', which is neither an exec nor a composite command", command.Id, subCommand))
+					continue
+				}
+				task.DependsOn = append(task.DependsOn, subLabel)
+			}
+		}
+		tasks.Tasks = append(tasks.Tasks, task)
+	}
+	if err := errors.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func taskGroup(group *workspaces.CommandGroup) *TaskGroup {
+	if group == nil {
+		return nil
+	}
+	switch group.Kind {
+	case workspaces.BuildCommandGroupKind:
+		return &TaskGroup{Kind: BuildTaskGroup, IsDefault: group.IsDefault}
+	case workspaces.TestCommandGroupKind:
+		return &TaskGroup{Kind: TestTaskGroup, IsDefault: group.IsDefault}
+	}
+	return nil
+}
+
+// LaunchToCommands converts the debug configurations of a VS Code `launch.json` file to `vscodeLaunch` commands
+// of the `debug` command group, so that each configuration can be started on its own:
+//
+// - each configuration becomes a command whose inlined content is a `launch.json` file with only this configuration,
+//
+// - each compound becomes a command whose inlined content contains the compound and the configurations it starts.
+//
+// Command ids are derived from the names of the configurations and compounds.
+// Returns an error for each compound that references a configuration that doesn't exist.
+func LaunchToCommands(launch *LaunchFile) ([]workspaces.Command, error) {
+	var names []string
+	configurations := map[string]LaunchConfiguration{}
+	for _, configuration := range launch.Configurations {
+		names = append(names, configuration.Name)
+		configurations[configuration.Name] = configuration
+	}
+	for _, compound := range launch.Compounds {
+		names = append(names, compound.Name)
+	}
+	ids := commandIds(names)
+
+	var errors *multierror.Error
+	var commands []workspaces.Command
+	for i, configuration := range launch.Configurations {
+		command, err := launchCommand(ids[i], &LaunchFile{Configurations: []LaunchConfiguration{configuration}})
+		if err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+		commands = append(commands, command)
+	}
+	for i, compound := range launch.Compounds {
+		content := &LaunchFile{Compounds: []LaunchCompound{compound}}
+		for _, name := range compound.Configurations {
+			configuration, exists := configurations[name]
+			if !exists {
+				errors = multierror.Append(errors, fmt.Errorf("compound '%s' references configuration '%s', which doesn't exist", compound.Name, name))
+				continue
+			}
+			content.Configurations = append(content.Configurations, configuration)
+		}
+		command, err := launchCommand(ids[len(launch.Configurations)+i], content)
+		if err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+		commands = append(commands, command)
+	}
+	if err := errors.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
+
+func launchCommand(id string, content *LaunchFile) (workspaces.Command, error) {
+	content.Version = LaunchVersion
+	inlined := &strings.Builder{}
+	encoder := json.NewEncoder(inlined)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(content); err != nil {
+		return workspaces.Command{}, err
+	}
+	launch := &workspaces.VscodeConfigurationCommand{}
+	launch.Group = &workspaces.CommandGroup{Kind: workspaces.DebugCommandGroupKind}
+	launch.Inlined = inlined.String()
+	return workspaces.Command{
+		Id:           id,
+		CommandUnion: workspaces.CommandUnion{VscodeLaunch: launch},
+	}, nil
+}
+
+// CommandsToLaunch generates the content of a VS Code `launch.json` file from the `vscodeLaunch` commands of a devfile,
+// by merging the configurations and compounds of their contents, loaded as in `LoadConfiguration`.
+//
+// Configurations and compounds defined by several commands are kept once, and must be identical.
+// Commands of other types are ignored.
+func CommandsToLaunch(commands []workspaces.Command, fetcher fetching.Fetcher, base string) (*LaunchFile, error) {
+	launch := &LaunchFile{Version: LaunchVersion}
+	// Commands and JSON content of the configurations and compounds already added, by name
+	owners := map[string]string{}
+	added := map[string]string{}
+	// Returns true if the configuration or compound should be added
+	add := func(kind string, name string, value interface{}, commandId string) (bool, error) {
+		content, err := json.Marshal(value)
+		if err != nil {
+			return false, err
+		}
+		key := kind + "/" + name
+		if owner, isAdded := owners[key]; isAdded {
+			if added[key] != string(content) {
+				return false, fmt.Errorf("commands '%s' and '%s' define different '%s' %ss", owner, commandId, name, kind)
+			}
+			return false, nil
+		}
+		owners[key] = commandId
+		added[key] = string(content)
+		return true, nil
+	}
+
+	var errors *multierror.Error
+	for _, command := range commands {
+		if command.VscodeLaunch == nil {
+			continue
+		}
+		content, err := LoadConfiguration(command.VscodeLaunch, fetcher, base)
+		if err != nil {
+			errors = multierror.Append(errors, fmt.Errorf("failed to load the configuration of command '%s': %v", command.Id, err))
+			continue
+		}
+		commandLaunch, err := ParseLaunch(content)
+		if err != nil {
+			errors = multierror.Append(errors, fmt.Errorf("command '%s': %v", command.Id, err))
+			continue
+		}
+		for _, configuration := range commandLaunch.Configurations {
+			if isNew, err := add("configuration", configuration.Name, configuration, command.Id); err != nil {
+				errors = multierror.Append(errors, err)
+			} else if isNew {
+				launch.Configurations = append(launch.Configurations, configuration)
+			}
+		}
+		for _, compound := range commandLaunch.Compounds {
+			if isNew, err := add("compound", compound.Name, compound, command.Id); err != nil {
+				errors = multierror.Append(errors, err)
+			} else if isNew {
+				launch.Compounds = append(launch.Compounds, compound)
+			}
+		}
+	}
+	if err := errors.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return launch, nil
+}
@@ -0,0 +1,244 @@
+package vscode
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	workspaces "github.com/devfile/api/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	content, err := ioutil.ReadFile(filepath.Join("test-fixtures", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func readCommands(t *testing.T) []workspaces.Command {
+	var commands []workspaces.Command
+	if err := yaml.Unmarshal(readFixture(t, "commands.yaml"), &commands); err != nil {
+		t.Fatal(err)
+	}
+	return commands
+}
+
+func TestStripJSONC(t *testing.T) {
+	content := `{
+  // comment
+  "url": "http://example.com/*not a comment*/", /* comment */
+  "list": ["a,]", "b\"//",],
+}`
+	var parsed map[string]interface{}
+	if assert.NoError(t, json.Unmarshal(StripJSONC([]byte(content)), &parsed)) {
+		assert.Equal(t, map[string]interface{}{
+			"url":  "http://example.com/*not a comment*/",
+			"list": []interface{}{"a,]", `b"//`},
+		}, parsed)
+	}
+}
+
+func TestTasksToCommands(t *testing.T) {
+	command := &workspaces.VscodeConfigurationCommand{}
+	command.Uri = "tasks.json"
+	content, err := LoadConfiguration(command, nil, filepath.Join("test-fixtures", "devfile.yaml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	tasks, err := ParseTasks(content)
+	if !assert.NoError(t, err) {
+		return
+	}
+	commands, err := TasksToCommands(tasks, "tools")
+	if assert.NoError(t, err) {
+		assert.Equal(t, readCommands(t), commands)
+	}
+}
+
+func TestTasksThatCannotBeConverted(t *testing.T) {
+	tasks, err := ParseTasks([]byte(`{
+  "version": "2.0.0",
+  "tasks": [
+    { "label": "lint", "type": "npm", "script": "lint" },
+    { "label": "all", "dependsOn": "missing" },
+    { "label": "deploy", "type": "shell", "command": "./deploy.sh", "dependsOn": "lint" }
+  ]
+}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = TasksToCommands(tasks, "tools")
+	if assert.Error(t, err) {
+		assert.Equal(t, `3 errors occurred:
+	* task 'lint' has type 'npm', but only 'shell' and 'process' tasks can be converted
+	* task 'all' depends on task 'missing', which doesn't exist
+	* task 'deploy' both runs a command and depends on other tasks, which is not supported`, strings.TrimSpace(err.Error()))
+	}
+}
+
+func TestCommandsToTasks(t *testing.T) {
+	commands := append(readCommands(t),
+		workspaces.Command{
+			Id: "run",
+			CommandUnion: workspaces.CommandUnion{
+				Exec: &workspaces.ExecCommand{
+					LabeledCommand: workspaces.LabeledCommand{
+						BaseCommand: workspaces.BaseCommand{
+							Group: &workspaces.CommandGroup{Kind: workspaces.RunCommandGroupKind, IsDefault: true},
+						},
+					},
+					CommandLine: "npm start",
+					WorkingDir:  "$PROJECTS_ROOT/app",
+					Component:   "tools",
+				},
+			},
+		},
+		workspaces.Command{
+			Id: "deploy",
+			CommandUnion: workspaces.CommandUnion{
+				Apply: &workspaces.ApplyCommand{Component: "deployment"},
+			},
+		})
+
+	tasks, err := CommandsToTasks(commands)
+	if !assert.NoError(t, err) {
+		return
+	}
+	generated, err := json.MarshalIndent(tasks, "", "  ")
+	if assert.NoError(t, err) {
+		assert.Equal(t, strings.TrimSpace(string(readFixture(t, "generated-tasks.json"))), string(generated))
+	}
+
+	reparsed, err := ParseTasks(generated)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tasks, reparsed)
+	}
+}
+
+func TestCommandsWithConflictingLabels(t *testing.T) {
+	commands := readCommands(t)
+	commands[1].Exec.Label = "Test"
+	_, err := CommandsToTasks(commands)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "commands 'build' and 'test' would both generate a task with label 'Test'")
+	}
+}
+
+func TestParseLaunch(t *testing.T) {
+	launch, err := ParseLaunch(readFixture(t, "launch.json"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, LaunchVersion, launch.Version)
+	if assert.Len(t, launch.Configurations, 2) {
+		attach := launch.Configurations[0]
+		assert.Equal(t, "Attach to server", attach.Name)
+		assert.Equal(t, "node", attach.Type)
+		assert.Equal(t, "attach", attach.Request)
+		assert.Equal(t, json.RawMessage("9229"), attach.Attributes["port"])
+		assert.Equal(t, "Build", launch.Configurations[1].PreLaunchTask)
+	}
+	assert.Equal(t, []LaunchCompound{{Name: "Server and tests", Configurations: []string{"Attach to server", "Launch tests"}}}, launch.Compounds)
+
+	marshaled, err := json.Marshal(launch)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, string(StripJSONC(readFixture(t, "launch.json"))), string(marshaled))
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		value    string
+		quoting  string
+		expected string
+	}{
+		{value: "--watch", expected: "--watch"},
+		{value: "my file.txt", expected: `"my file.txt"`},
+		{value: `say "hi" $(rm -rf /)`, expected: `"say \"hi\" \$(rm -rf /)"`},
+		{value: "${NAME} and $HOME", quoting: "weak", expected: `"${NAME} and $HOME"`},
+		{value: "`id` ${NAME:-x} \\ $", quoting: "weak", expected: "\"\\`id\\` \\${NAME:-x} \\\\ \\$\""},
+		{value: "it's $HOME", quoting: "strong", expected: `'it'\''s $HOME'`},
+		{value: "plain", quoting: "strong", expected: "plain"},
+		{value: `a b"$`, quoting: "escape", expected: `a\ b\"\$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.expected, quote(tt.value, tt.quoting))
+		})
+	}
+}
+
+func TestCommandIds(t *testing.T) {
+	long := strings.Repeat("a", 62) + " task"
+	ids := commandIds([]string{"Build", "build", long, long, "!!!"})
+	assert.Equal(t, []string{"build", "build-2", strings.Repeat("a", 62), strings.Repeat("a", 61) + "-2", "task"}, ids)
+}
+
+func TestLaunchToCommands(t *testing.T) {
+	launch, err := ParseLaunch(readFixture(t, "launch.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	commands, err := LaunchToCommands(launch)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var ids []string
+	for _, command := range commands {
+		ids = append(ids, command.Id)
+		if assert.NotNil(t, command.VscodeLaunch, command.Id) {
+			assert.Equal(t, &workspaces.CommandGroup{Kind: workspaces.DebugCommandGroupKind}, command.VscodeLaunch.Group)
+		}
+	}
+	assert.Equal(t, []string{"attach-to-server", "launch-tests", "server-and-tests"}, ids)
+
+	assertSameLaunch(t, &LaunchFile{Version: LaunchVersion, Configurations: launch.Configurations[:1]}, []byte(commands[0].VscodeLaunch.Inlined))
+	assert.Contains(t, commands[0].VscodeLaunch.Inlined, `"<node_internals>/**"`)
+
+	generated, err := CommandsToLaunch(commands, nil, "")
+	if assert.NoError(t, err) {
+		marshaled, _ := json.Marshal(generated)
+		assertSameLaunch(t, launch, marshaled)
+	}
+
+	launch.Compounds = append(launch.Compounds, LaunchCompound{Name: "Missing", Configurations: []string{"Attach to client"}})
+	_, err = LaunchToCommands(launch)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "compound 'Missing' references configuration 'Attach to client', which doesn't exist")
+	}
+}
+
+func assertSameLaunch(t *testing.T, expected *LaunchFile, actual []byte) {
+	marshaled, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.JSONEq(t, string(marshaled), string(StripJSONC(actual)))
+}
+
+func TestCommandsToLaunchWithConflictingConfigurations(t *testing.T) {
+	launch, err := ParseLaunch(readFixture(t, "launch.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	commands, err := LaunchToCommands(launch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	launch.Configurations[0].Attributes["port"] = json.RawMessage("9230")
+	changed, err := LaunchToCommands(&LaunchFile{Configurations: launch.Configurations[:1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed[0].Id = "attach-to-server-9230"
+
+	_, err = CommandsToLaunch(append(commands, changed...), nil, "")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "commands 'attach-to-server' and 'attach-to-server-9230' define different 'Attach to server' configurations")
+	}
+}